@@ -0,0 +1,503 @@
+// Package boundary defines an Analyzer that enforces classified errors at package API boundaries.
+//
+// For each package listed by the -packages flag, the analyzer reports exported
+// functions and methods that may return an error which is not provably created
+// by an errdef Factory (New, Errorf, Wrap, Wrapf, Join, Recover) or passed
+// through a translation function listed by the -translators flag.
+//
+// Returned errors are traced through local variables, branches, and calls to
+// other functions. An error returned only after an errors.Is check against an
+// errdef Definition, as in
+//
+//	if errors.Is(err, ErrNotFound) {
+//		return err
+//	}
+//
+// is classified as well. A function in any analyzed package whose returned errors
+// are all classified counts as a classifying call as well, so helper
+// functions and lower layers do not have to be whitelisted.
+//
+// A report can be suppressed by a comment on the return statement or on the
+// line above it, or for the whole function in its doc comment:
+//
+//	//errdefboundary:ignore reason
+package boundary
+
+import (
+	"cmp"
+	"go/ast"
+	"go/build"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+const errdefPkgPath = "github.com/shiwano/errdef"
+
+const ignoreDirective = "//errdefboundary:ignore"
+
+// constructors are the methods of errdef.Factory that create classified
+// errors. Recover is handled separately, since it returns the error of its
+// function unchanged unless the function panics.
+var constructors = []string{"New", "Errorf", "Wrap", "Wrapf", "Join"}
+
+const doc = `enforce classified errors at package API boundaries
+
+Reports exported functions of the boundary packages (-packages) that may return
+an error not created by an errdef Factory or passed through a translation
+function (-translators). Use "//errdefboundary:ignore" to suppress a report.`
+
+// Analyzer enforces classified errors at package API boundaries.
+var Analyzer = &analysis.Analyzer{
+	Name:      "errdefboundary",
+	Doc:       doc,
+	URL:       "https://pkg.go.dev/github.com/shiwano/errdef/analysis/boundary",
+	Run:       run,
+	FactTypes: []analysis.Fact{new(classifiedFact)},
+}
+
+var (
+	boundaryPackages listFlag
+	translators      listFlag
+)
+
+func init() {
+	Analyzer.Flags.Var(&boundaryPackages, "packages",
+		`comma-separated list of boundary package paths; a trailing "/..." matches subpackages`)
+	Analyzer.Flags.Var(&translators, "translators",
+		`comma-separated list of translation functions in types.Func.FullName form (e.g., "example.com/errs.Translate" or "(*example.com/errs.Mapper).Map")`)
+}
+
+type (
+	// classifiedFact marks a function whose returned errors are all classified.
+	classifiedFact struct{}
+
+	listFlag []string
+
+	checker struct {
+		pass     *analysis.Pass
+		memo     map[*ssa.Function]bool
+		visiting map[*ssa.Function]bool
+	}
+)
+
+func (*classifiedFact) AFact() {}
+
+func (*classifiedFact) String() string { return "classified" }
+
+func (f *listFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *listFlag) Set(s string) error {
+	*f = nil
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*f = append(*f, v)
+		}
+	}
+	return nil
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	// Errors returned by the standard library are never classified,
+	// so there is no need to build SSA for it.
+	if isStdlib(pass) {
+		return nil, nil
+	}
+	srcFuncs := buildSSA(pass)
+	c := &checker{
+		pass:     pass,
+		memo:     make(map[*ssa.Function]bool),
+		visiting: make(map[*ssa.Function]bool),
+	}
+
+	for _, fn := range srcFuncs {
+		obj, ok := fn.Object().(*types.Func)
+		if !ok || len(errorResults(fn.Signature)) == 0 {
+			continue
+		}
+		if c.funcClassified(fn) {
+			pass.ExportObjectFact(obj, &classifiedFact{})
+		}
+	}
+
+	if !isBoundaryPackage(pass.Pkg.Path()) {
+		return nil, nil
+	}
+
+	ignored := ignoredLines(pass)
+	for _, fn := range srcFuncs {
+		if !isExportedAPI(fn) || hasIgnoreDoc(fn) {
+			continue
+		}
+		for _, ret := range returns(fn) {
+			for _, i := range errorResults(fn.Signature) {
+				if c.returnClassified(ret, ret.Results[i]) {
+					continue
+				}
+				pos := ret.Pos()
+				if !pos.IsValid() {
+					pos = fn.Pos()
+				}
+				if ignored[lineOf(pass.Fset, pos)] {
+					continue
+				}
+				pass.Reportf(pos, "%s returns an error that is not classified by an errdef Factory or a translation function", fn.Name())
+			}
+		}
+	}
+	return nil, nil
+}
+
+// buildSSA builds the SSA form of the package and returns its source functions,
+// including function literals, in source order.
+func buildSSA(pass *analysis.Pass) []*ssa.Function {
+	prog := ssa.NewProgram(pass.Fset, 0)
+	for _, p := range pass.Pkg.Imports() {
+		prog.CreatePackage(p, nil, nil, true)
+	}
+	pkg := prog.CreatePackage(pass.Pkg, pass.Files, pass.TypesInfo, false)
+	pkg.Build()
+
+	var funcs []*ssa.Function
+	var add func(fn *ssa.Function)
+	add = func(fn *ssa.Function) {
+		funcs = append(funcs, fn)
+		for _, anon := range fn.AnonFuncs {
+			add(anon)
+		}
+	}
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok {
+				if obj, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok {
+					if fn := prog.FuncValue(obj); fn != nil {
+						add(fn)
+					}
+				}
+			}
+		}
+	}
+	if init := pkg.Func("init"); init != nil {
+		for _, anon := range init.AnonFuncs {
+			add(anon) // function literals in package-level var initializers
+		}
+	}
+	slices.SortFunc(funcs, func(a, b *ssa.Function) int {
+		return cmp.Compare(a.Pos(), b.Pos())
+	})
+	return funcs
+}
+
+func isStdlib(pass *analysis.Pass) bool {
+	if len(pass.Files) == 0 {
+		return true
+	}
+	file := pass.Fset.File(pass.Files[0].Pos()).Name()
+	rel, err := filepath.Rel(filepath.Join(build.Default.GOROOT, "src"), file)
+	return err == nil && !strings.HasPrefix(rel, "..")
+}
+
+// funcClassified reports whether all errors returned by fn are classified.
+func (c *checker) funcClassified(fn *ssa.Function) bool {
+	if v, ok := c.memo[fn]; ok {
+		return v
+	}
+	if c.visiting[fn] {
+		return true // optimistic for recursive calls
+	}
+	if len(fn.Blocks) == 0 {
+		return false
+	}
+	c.visiting[fn] = true
+	defer delete(c.visiting, fn)
+
+	result := true
+	for _, ret := range returns(fn) {
+		for _, i := range errorResults(fn.Signature) {
+			if !c.returnClassified(ret, ret.Results[i]) {
+				result = false
+			}
+		}
+	}
+	c.memo[fn] = result
+	return result
+}
+
+// returnClassified reports whether the error v returned by ret is provably
+// classified, either by its origin or by an errors.Is check against an errdef
+// value that guards the return.
+func (c *checker) returnClassified(ret *ssa.Return, v ssa.Value) bool {
+	return c.classified(v, make(map[ssa.Value]bool)) || guardedByIs(ret.Block(), v, make(map[*ssa.BasicBlock]bool))
+}
+
+// guardedByIs reports whether every path to b passes through the true branch
+// of errors.Is(v, target), where target is an errdef Definition or Error.
+// Such a check proves that v wraps a classified error.
+func guardedByIs(b *ssa.BasicBlock, v ssa.Value, visiting map[*ssa.BasicBlock]bool) bool {
+	if len(b.Preds) == 0 || visiting[b] {
+		return false
+	}
+	visiting[b] = true
+	defer delete(visiting, b)
+
+	for _, pred := range b.Preds {
+		if isErrorsIsBranch(pred, b, v) {
+			continue
+		}
+		if !guardedByIs(pred, v, visiting) {
+			return false
+		}
+	}
+	return true
+}
+
+// isErrorsIsBranch reports whether from ends with `if errors.Is(v, target)`
+// whose true branch is to.
+func isErrorsIsBranch(from, to *ssa.BasicBlock, v ssa.Value) bool {
+	if len(from.Instrs) == 0 || len(from.Succs) != 2 || from.Succs[0] != to {
+		return false
+	}
+	cond, ok := from.Instrs[len(from.Instrs)-1].(*ssa.If)
+	if !ok {
+		return false
+	}
+	call, ok := cond.Cond.(*ssa.Call)
+	if !ok {
+		return false
+	}
+	callee := call.Common().StaticCallee()
+	if callee == nil || len(call.Common().Args) != 2 {
+		return false
+	}
+	if obj, ok := callee.Object().(*types.Func); !ok || obj.FullName() != "errors.Is" {
+		return false
+	}
+	args := call.Common().Args
+	return unwrapInterface(args[0]) == unwrapInterface(v) && isErrdefType(unwrapInterface(args[1]).Type())
+}
+
+// unwrapInterface returns the value converted to an interface by v, if any.
+func unwrapInterface(v ssa.Value) ssa.Value {
+	for {
+		switch x := v.(type) {
+		case *ssa.MakeInterface:
+			v = x.X
+		case *ssa.ChangeInterface:
+			v = x.X
+		default:
+			return v
+		}
+	}
+}
+
+// classified reports whether v is provably a classified error (or nil).
+func (c *checker) classified(v ssa.Value, seen map[ssa.Value]bool) bool {
+	if seen[v] {
+		return true
+	}
+	seen[v] = true
+
+	if isErrdefType(v.Type()) {
+		return true
+	}
+
+	switch v := v.(type) {
+	case *ssa.Const:
+		return v.IsNil()
+	case *ssa.MakeInterface:
+		return isErrdefType(v.X.Type())
+	case *ssa.ChangeInterface:
+		return c.classified(v.X, seen)
+	case *ssa.Phi:
+		for _, e := range v.Edges {
+			if !c.classified(e, seen) {
+				return false
+			}
+		}
+		return true
+	case *ssa.Extract:
+		if call, ok := v.Tuple.(*ssa.Call); ok {
+			return c.callClassified(call.Common())
+		}
+	case *ssa.Call:
+		return c.callClassified(v.Common())
+	case *ssa.UnOp:
+		if v.Op != token.MUL {
+			return false
+		}
+		alloc, ok := v.X.(*ssa.Alloc)
+		if !ok {
+			return false
+		}
+		for _, ref := range *alloc.Referrers() {
+			if store, ok := ref.(*ssa.Store); ok && store.Addr == alloc {
+				if !c.classified(store.Val, seen) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (c *checker) callClassified(common *ssa.CallCommon) bool {
+	if common.IsInvoke() {
+		m := common.Method
+		if isErrdefObject(m) {
+			if m.Name() == "Recover" {
+				return len(common.Args) == 1 && c.funcValueClassified(common.Args[0])
+			}
+			return slices.Contains(constructors, m.Name())
+		}
+		return isTranslator(m)
+	}
+
+	callee := common.StaticCallee()
+	if callee == nil {
+		return false
+	}
+	if callee.Origin() != nil {
+		callee = callee.Origin()
+	}
+	if obj, ok := callee.Object().(*types.Func); ok {
+		if isTranslator(obj) {
+			return true
+		}
+		if obj.Pkg() != c.pass.Pkg {
+			return c.pass.ImportObjectFact(obj, new(classifiedFact))
+		}
+	}
+	return c.funcClassified(callee)
+}
+
+// funcValueClassified reports whether v is a function whose returned errors
+// are all classified, such as the function passed to Factory.Recover.
+func (c *checker) funcValueClassified(v ssa.Value) bool {
+	switch v := v.(type) {
+	case *ssa.Function:
+		return c.funcClassified(v)
+	case *ssa.MakeClosure:
+		if fn, ok := v.Fn.(*ssa.Function); ok {
+			return c.funcClassified(fn)
+		}
+	}
+	return false
+}
+
+func returns(fn *ssa.Function) []*ssa.Return {
+	var rets []*ssa.Return
+	for _, b := range fn.Blocks {
+		if len(b.Instrs) == 0 {
+			continue
+		}
+		if ret, ok := b.Instrs[len(b.Instrs)-1].(*ssa.Return); ok {
+			rets = append(rets, ret)
+		}
+	}
+	return rets
+}
+
+// errorResults returns the indices of the results of type error.
+func errorResults(sig *types.Signature) []int {
+	var indices []int
+	for i := range sig.Results().Len() {
+		if types.Identical(sig.Results().At(i).Type(), types.Universe.Lookup("error").Type()) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// isExportedAPI reports whether fn is an exported function or method.
+// Exported methods of unexported types are included, since they can be
+// called through interfaces.
+func isExportedAPI(fn *ssa.Function) bool {
+	obj, ok := fn.Object().(*types.Func)
+	return ok && obj.Exported()
+}
+
+func isErrdefType(t types.Type) bool {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	return ok && isErrdefObject(named.Obj())
+}
+
+func isErrdefObject(obj types.Object) bool {
+	return obj.Pkg() != nil && obj.Pkg().Path() == errdefPkgPath
+}
+
+func isTranslator(fn *types.Func) bool {
+	if origin := fn.Origin(); origin != nil {
+		fn = origin
+	}
+	name := fn.FullName()
+	for _, t := range translators {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isBoundaryPackage(path string) bool {
+	for _, p := range boundaryPackages {
+		if prefix, ok := strings.CutSuffix(p, "/..."); ok {
+			if path == prefix || strings.HasPrefix(path, prefix+"/") {
+				return true
+			}
+		} else if path == p {
+			return true
+		}
+	}
+	return false
+}
+
+func hasIgnoreDoc(fn *ssa.Function) bool {
+	decl, ok := fn.Syntax().(*ast.FuncDecl)
+	if !ok || decl.Doc == nil {
+		return false
+	}
+	for _, c := range decl.Doc.List {
+		if strings.HasPrefix(c.Text, ignoreDirective) {
+			return true
+		}
+	}
+	return false
+}
+
+type fileLine struct {
+	file string
+	line int
+}
+
+// ignoredLines returns the lines covered by ignore directives:
+// the line of the directive and the line below it.
+func ignoredLines(pass *analysis.Pass) map[fileLine]bool {
+	lines := make(map[fileLine]bool)
+	for _, f := range pass.Files {
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				if !strings.HasPrefix(c.Text, ignoreDirective) {
+					continue
+				}
+				k := lineOf(pass.Fset, c.Pos())
+				lines[k] = true
+				lines[fileLine{k.file, k.line + 1}] = true
+			}
+		}
+	}
+	return lines
+}
+
+func lineOf(fset *token.FileSet, pos token.Pos) fileLine {
+	p := fset.Position(pos)
+	return fileLine{p.Filename, p.Line}
+}
@@ -0,0 +1,22 @@
+package boundary_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/shiwano/errdef/analysis/boundary"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	if err := boundary.Analyzer.Flags.Set("packages", "repo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := boundary.Analyzer.Flags.Set("translators", "errs.Translate,(*errs.Mapper).Map"); err != nil {
+		t.Fatal(err)
+	}
+
+	analysistest.Run(t, testdata, boundary.Analyzer, "repo")
+}
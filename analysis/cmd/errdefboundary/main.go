@@ -0,0 +1,20 @@
+// Command errdefboundary reports exported functions of boundary packages
+// that may return errors not classified by errdef.
+//
+// It can be run standalone or through go vet:
+//
+//	errdefboundary -packages=example.com/app/repository/... ./...
+//	go vet -vettool=$(which errdefboundary) -packages=example.com/app/repository/... ./...
+//
+// Run errdefboundary -help for the list of flags.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/shiwano/errdef/analysis/boundary"
+)
+
+func main() {
+	singlechecker.Main(boundary.Analyzer)
+}
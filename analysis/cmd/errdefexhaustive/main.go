@@ -0,0 +1,20 @@
+// Command errdefexhaustive reports errdef.Match chains that do not handle
+// all definitions of a declared set.
+//
+// It can be run standalone or through go vet:
+//
+//	errdefexhaustive ./...
+//	go vet -vettool=$(which errdefexhaustive) ./...
+//
+// Run errdefexhaustive -help for the list of flags.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/shiwano/errdef/analysis/exhaustive"
+)
+
+func main() {
+	singlechecker.Main(exhaustive.Analyzer)
+}
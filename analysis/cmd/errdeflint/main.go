@@ -0,0 +1,19 @@
+// Command errdeflint reports misuse of errdef.
+//
+// It can be run standalone or through go vet:
+//
+//	errdeflint ./...
+//	go vet -vettool=$(which errdeflint) ./...
+//
+// Run errdeflint -help for the list of checks and flags.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/shiwano/errdef/analysis/errdeflint"
+)
+
+func main() {
+	singlechecker.Main(errdeflint.Analyzer)
+}
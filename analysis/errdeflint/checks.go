@@ -0,0 +1,137 @@
+package errdeflint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+func reportCompare(pass *analysis.Pass, ins *inspector.Inspector) {
+	if pass.Pkg.Path() == errdefPkgPath {
+		return // the implementation compares definitions by identity
+	}
+	def := lookupErrdefType(pass.Pkg, "Definition")
+	if def == nil {
+		return
+	}
+	iface, ok := def.Underlying().(*types.Interface)
+	if !ok {
+		return
+	}
+	errorIface := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	implements := func(expr ast.Expr, iface *types.Interface) bool {
+		tv, ok := pass.TypesInfo.Types[expr]
+		if !ok || tv.IsNil() || tv.Type == nil {
+			return false
+		}
+		return types.Implements(tv.Type, iface)
+	}
+	// isMisuse reports whether x is a Definition and y is another error.
+	// Comparisons between Definitions (or Factories) are identity checks and are allowed.
+	isMisuse := func(x, y ast.Expr) bool {
+		return implements(x, iface) && !implements(y, iface) && implements(y, errorIface)
+	}
+
+	filter := []ast.Node{(*ast.BinaryExpr)(nil), (*ast.SwitchStmt)(nil)}
+	ins.Preorder(filter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.BinaryExpr:
+			if n.Op != token.EQL && n.Op != token.NEQ {
+				return
+			}
+			if isMisuse(n.X, n.Y) || isMisuse(n.Y, n.X) {
+				pass.Reportf(n.OpPos, "comparison with a Definition using %s; use errors.Is instead", n.Op)
+			}
+		case *ast.SwitchStmt:
+			if n.Tag == nil {
+				return
+			}
+			for _, stmt := range n.Body.List {
+				for _, expr := range stmt.(*ast.CaseClause).List {
+					if isMisuse(expr, n.Tag) || isMisuse(n.Tag, expr) {
+						pass.Reportf(expr.Pos(), "switch case compares with a Definition using ==; use errors.Is instead")
+					}
+				}
+			}
+		}
+	})
+}
+
+func reportFactoryVars(pass *analysis.Pass) {
+	factory := lookupErrdefType(pass.Pkg, "Factory")
+	if factory == nil {
+		return
+	}
+	scope := pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		v, ok := scope.Lookup(name).(*types.Var)
+		if !ok || !types.Identical(v.Type(), factory) {
+			continue
+		}
+		if file := pass.Fset.File(v.Pos()); file != nil && strings.HasSuffix(file.Name(), "_test.go") {
+			continue
+		}
+		pass.Reportf(v.Pos(), "Factory %s is stored in a package-level var; store the Definition and call With or WithOptions per error instead", v.Name())
+	}
+}
+
+func reportWrapf(pass *analysis.Pass, ins *inspector.Inspector) {
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := errdefFunc(pass.TypesInfo, call)
+		if !ok || fn.Name() != "Wrapf" || fn.Signature().Recv() == nil || len(call.Args) < 2 {
+			return
+		}
+
+		if tv, ok := pass.TypesInfo.Types[call.Args[1]]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			if strings.Contains(constant.StringVal(tv.Value), "%w") {
+				pass.Reportf(call.Args[1].Pos(), "Wrapf format contains %%w; the cause is appended automatically")
+				return
+			}
+		}
+
+		cause := call.Args[0]
+		for _, arg := range call.Args[2:] {
+			if refersToCause(pass.TypesInfo, arg, cause) {
+				pass.Reportf(arg.Pos(), "Wrapf is passed the cause as a format argument; Wrapf already appends \": \" + cause.Error()")
+				return
+			}
+		}
+	})
+}
+
+// refersToCause reports whether arg is the cause expression itself or cause.Error().
+func refersToCause(info *types.Info, arg, cause ast.Expr) bool {
+	arg = astutil.Unparen(arg)
+	if call, ok := arg.(*ast.CallExpr); ok && len(call.Args) == 0 {
+		if sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr); ok && sel.Sel.Name == "Error" {
+			arg = astutil.Unparen(sel.X)
+		}
+	}
+	return sameExpr(info, arg, astutil.Unparen(cause))
+}
+
+func sameExpr(info *types.Info, x, y ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.Ident:
+		y, ok := y.(*ast.Ident)
+		if !ok {
+			return false
+		}
+		xo, yo := info.ObjectOf(x), info.ObjectOf(y)
+		return xo != nil && xo == yo
+	case *ast.SelectorExpr:
+		y, ok := y.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		return info.ObjectOf(x.Sel) == info.ObjectOf(y.Sel) && sameExpr(info, x.X, y.X)
+	}
+	return false
+}
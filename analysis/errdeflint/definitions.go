@@ -0,0 +1,192 @@
+package errdeflint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+type (
+	// definitionsFact records the kinds and field names defined in a package,
+	// so that duplicates can be detected across packages.
+	definitionsFact struct {
+		// Kinds maps each kind to the position where it is defined.
+		Kinds map[string]string
+		// Fields maps each field name to its type and the position where it is defined.
+		Fields map[string]fieldFact
+	}
+
+	fieldFact struct {
+		Type     string
+		Position string
+	}
+
+	// definitions holds the Define and DefineField calls found in a package.
+	definitions struct {
+		kinds  []definedName
+		fields []definedName
+	}
+
+	definedName struct {
+		name string
+		typ  string
+		call *ast.CallExpr
+	}
+)
+
+func (*definitionsFact) AFact() {}
+
+func (f *definitionsFact) String() string {
+	return "definitions(" + strings.Join(slices.Sorted(maps.Keys(f.Kinds)), ", ") + ")"
+}
+
+func collectDefinitions(pass *analysis.Pass, ins *inspector.Inspector) *definitions {
+	defs := &definitions{}
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if len(call.Args) == 0 || isTestFile(pass, call) {
+			return
+		}
+		name, ok := constantString(pass.TypesInfo, call.Args[0])
+		if !ok {
+			return
+		}
+		switch {
+		case isErrdefFunc(pass.TypesInfo, call, "Define"):
+			defs.kinds = append(defs.kinds, definedName{name: name, call: call})
+		case isErrdefFunc(pass.TypesInfo, call, "DefineField"):
+			typ := pass.TypesInfo.TypeOf(call)
+			tuple, ok := typ.(*types.Tuple)
+			if !ok || tuple.Len() != 2 {
+				return
+			}
+			ctor, ok := tuple.At(0).Type().(*types.Named)
+			if !ok || ctor.TypeArgs().Len() != 1 {
+				return
+			}
+			defs.fields = append(defs.fields, definedName{
+				name: name,
+				typ:  types.TypeString(ctor.TypeArgs().At(0), nil),
+				call: call,
+			})
+		}
+	})
+
+	fact := &definitionsFact{
+		Kinds:  make(map[string]string),
+		Fields: make(map[string]fieldFact),
+	}
+	for _, k := range defs.kinds {
+		if _, ok := fact.Kinds[k.name]; !ok {
+			fact.Kinds[k.name] = pass.Fset.Position(k.call.Pos()).String()
+		}
+	}
+	for _, f := range defs.fields {
+		if _, ok := fact.Fields[f.name]; !ok {
+			fact.Fields[f.name] = fieldFact{Type: f.typ, Position: pass.Fset.Position(f.call.Pos()).String()}
+		}
+	}
+	if len(fact.Kinds) > 0 || len(fact.Fields) > 0 {
+		pass.ExportPackageFact(fact)
+	}
+	return defs
+}
+
+func reportDuplicates(pass *analysis.Pass, defs *definitions) {
+	imported := pass.AllPackageFacts()
+
+	if checkKind {
+		seen := make(map[string]token.Pos)
+		for _, k := range defs.kinds {
+			if pos, ok := seen[k.name]; ok {
+				pass.Reportf(k.call.Pos(), "kind %q is already defined at %s", k.name, pass.Fset.Position(pos))
+				continue
+			}
+			seen[k.name] = k.call.Pos()
+
+			for _, pf := range imported {
+				fact, ok := pf.Fact.(*definitionsFact)
+				if !ok || pf.Package == pass.Pkg {
+					continue
+				}
+				if pos, ok := fact.Kinds[k.name]; ok {
+					pass.Reportf(k.call.Pos(), "kind %q is already defined in package %s at %s", k.name, pf.Package.Path(), pos)
+					break
+				}
+			}
+		}
+	}
+
+	if checkField {
+		seen := make(map[string]definedName)
+		for _, f := range defs.fields {
+			if prev, ok := seen[f.name]; ok {
+				if prev.typ != f.typ {
+					pass.Reportf(f.call.Pos(), "field %q is already defined with type %s at %s", f.name, prev.typ, pass.Fset.Position(prev.call.Pos()))
+				}
+				continue
+			}
+			seen[f.name] = f
+
+			for _, pf := range imported {
+				fact, ok := pf.Fact.(*definitionsFact)
+				if !ok || pf.Package == pass.Pkg {
+					continue
+				}
+				if prev, ok := fact.Fields[f.name]; ok && prev.Type != f.typ {
+					pass.Reportf(f.call.Pos(), "field %q is already defined with type %s in package %s at %s", f.name, prev.Type, pf.Package.Path(), prev.Position)
+					break
+				}
+			}
+		}
+	}
+}
+
+func reportScope(pass *analysis.Pass, ins *inspector.Inspector) {
+	filter := []ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}
+	ins.Preorder(filter, func(n ast.Node) {
+		if isTestFile(pass, n) {
+			return
+		}
+		var body *ast.BlockStmt
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		}
+		if body == nil {
+			return
+		}
+		ast.Inspect(body, func(n ast.Node) bool {
+			if _, ok := n.(*ast.FuncLit); ok {
+				return false // reported on its own
+			}
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			for _, name := range []string{"Define", "DefineField"} {
+				if isErrdefFunc(pass.TypesInfo, call, name) {
+					pass.Reportf(call.Pos(), "errdef.%s should be called in a package-level var declaration", name)
+				}
+			}
+			return true
+		})
+	})
+}
+
+func constantString(info *types.Info, expr ast.Expr) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
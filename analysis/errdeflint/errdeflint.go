@@ -0,0 +1,182 @@
+// Package errdeflint defines an Analyzer that reports misuse of errdef.
+//
+// The analyzer runs the following checks, each of which can be disabled by a flag:
+//
+//   - kind: duplicate Kind strings passed to errdef.Define, within a package
+//     or across the packages it depends on.
+//   - field: duplicate errdef.DefineField names declared with different types.
+//   - scope: errdef.Define and errdef.DefineField calls outside package-level
+//     var declarations (test files are excluded).
+//   - compare: == and != comparisons (including switch cases) against a
+//     Definition, which should use errors.Is instead.
+//   - factory: Factories stored in package-level vars, which should be created
+//     per call with Definition.With or Definition.WithOptions.
+//   - wrapf: Factory.Wrapf format strings that contain %w, or pass the cause
+//     as an argument, since Wrapf already appends ": " + cause.Error().
+//
+// A report can be suppressed by a comment on the reported line or on the
+// line above it:
+//
+//	//errdeflint:ignore reason
+package errdeflint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const errdefPkgPath = "github.com/shiwano/errdef"
+
+const ignoreDirective = "//errdeflint:ignore"
+
+const doc = `report misuse of errdef
+
+Checks for duplicate kinds and field names, Define/DefineField calls outside
+package-level vars, == comparisons against Definitions, Factories stored in
+package-level vars, and Wrapf format strings that repeat the cause.
+Use "//errdeflint:ignore" to suppress a report.`
+
+// Analyzer reports misuse of errdef.
+var Analyzer = &analysis.Analyzer{
+	Name:      "errdeflint",
+	Doc:       doc,
+	URL:       "https://pkg.go.dev/github.com/shiwano/errdef/analysis/errdeflint",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(definitionsFact)},
+}
+
+var (
+	checkKind    bool
+	checkField   bool
+	checkScope   bool
+	checkCompare bool
+	checkFactory bool
+	checkWrapf   bool
+)
+
+func init() {
+	Analyzer.Flags.BoolVar(&checkKind, "kind", true, "report duplicate kinds")
+	Analyzer.Flags.BoolVar(&checkField, "field", true, "report duplicate field names with different types")
+	Analyzer.Flags.BoolVar(&checkScope, "scope", true, "report Define/DefineField calls outside package-level vars")
+	Analyzer.Flags.BoolVar(&checkCompare, "compare", true, "report == comparisons against Definitions")
+	Analyzer.Flags.BoolVar(&checkFactory, "factory", true, "report Factories stored in package-level vars")
+	Analyzer.Flags.BoolVar(&checkWrapf, "wrapf", true, "report Wrapf format strings that repeat the cause")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	ignored := ignoredLines(pass)
+	report := pass.Report
+	pass.Report = func(d analysis.Diagnostic) {
+		if !ignored[lineOf(pass.Fset, d.Pos)] {
+			report(d)
+		}
+	}
+
+	defs := collectDefinitions(pass, ins)
+	if checkKind || checkField {
+		reportDuplicates(pass, defs)
+	}
+	if checkScope {
+		reportScope(pass, ins)
+	}
+	if checkCompare {
+		reportCompare(pass, ins)
+	}
+	if checkFactory {
+		reportFactoryVars(pass)
+	}
+	if checkWrapf {
+		reportWrapf(pass, ins)
+	}
+	return nil, nil
+}
+
+// errdefFunc returns the errdef function or method called by call, if any.
+func errdefFunc(info *types.Info, call *ast.CallExpr) (*types.Func, bool) {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != errdefPkgPath {
+		return nil, false
+	}
+	return fn, true
+}
+
+// isErrdefFunc reports whether call is a call to the package-level errdef function name.
+func isErrdefFunc(info *types.Info, call *ast.CallExpr, name string) bool {
+	fn, ok := errdefFunc(info, call)
+	if !ok || fn.Name() != name {
+		return false
+	}
+	return fn.Signature().Recv() == nil
+}
+
+// lookupErrdefType returns the named type declared in the errdef package,
+// if the package depends on it directly or indirectly.
+func lookupErrdefType(pkg *types.Package, name string) types.Type {
+	errdefPkg := findPackage(pkg, errdefPkgPath, make(map[*types.Package]bool))
+	if errdefPkg == nil {
+		return nil
+	}
+	if obj, ok := errdefPkg.Scope().Lookup(name).(*types.TypeName); ok {
+		return obj.Type()
+	}
+	return nil
+}
+
+func findPackage(pkg *types.Package, path string, visited map[*types.Package]bool) *types.Package {
+	if pkg.Path() == path {
+		return pkg
+	}
+	visited[pkg] = true
+	for _, imp := range pkg.Imports() {
+		if visited[imp] {
+			continue
+		}
+		if found := findPackage(imp, path, visited); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+type fileLine struct {
+	file string
+	line int
+}
+
+// ignoredLines returns the lines covered by ignore directives:
+// the line of the directive and the line below it.
+func ignoredLines(pass *analysis.Pass) map[fileLine]bool {
+	lines := make(map[fileLine]bool)
+	for _, f := range pass.Files {
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				if !strings.HasPrefix(c.Text, ignoreDirective) {
+					continue
+				}
+				k := lineOf(pass.Fset, c.Pos())
+				lines[k] = true
+				lines[fileLine{k.file, k.line + 1}] = true
+			}
+		}
+	}
+	return lines
+}
+
+func lineOf(fset *token.FileSet, pos token.Pos) fileLine {
+	p := fset.Position(pos)
+	return fileLine{p.Filename, p.Line}
+}
+
+func isTestFile(pass *analysis.Pass, node ast.Node) bool {
+	return strings.HasSuffix(pass.Fset.File(node.Pos()).Name(), "_test.go")
+}
@@ -0,0 +1,33 @@
+package errdeflint_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/shiwano/errdef/analysis/errdeflint"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	t.Run("duplicates", func(t *testing.T) {
+		analysistest.Run(t, testdata, errdeflint.Analyzer, "a", "b")
+	})
+
+	t.Run("compare", func(t *testing.T) {
+		analysistest.Run(t, testdata, errdeflint.Analyzer, "compare")
+	})
+
+	t.Run("scope", func(t *testing.T) {
+		analysistest.Run(t, testdata, errdeflint.Analyzer, "scope", "scopetest")
+	})
+
+	t.Run("factory", func(t *testing.T) {
+		analysistest.Run(t, testdata, errdeflint.Analyzer, "factory")
+	})
+
+	t.Run("wrapf", func(t *testing.T) {
+		analysistest.Run(t, testdata, errdeflint.Analyzer, "wrapf")
+	})
+}
@@ -0,0 +1,11 @@
+package a // want package:`definitions\(conflict, not_found\)`
+
+import "github.com/shiwano/errdef"
+
+var (
+	ErrNotFound = errdef.Define("not_found")
+	ErrConflict = errdef.Define("conflict")
+
+	UserID, UserIDFrom = errdef.DefineField[string]("user_id")
+	Count, CountFrom   = errdef.DefineField[int]("count")
+)
@@ -0,0 +1,20 @@
+package b // want package:`definitions\(internal, not_found\)`
+
+import (
+	"a"
+
+	"github.com/shiwano/errdef"
+)
+
+var _ = a.ErrNotFound
+
+var (
+	ErrNotFound = errdef.Define("not_found") // want `kind "not_found" is already defined in package a at .*a.go:6:16`
+	ErrInternal = errdef.Define("internal")
+	ErrUnknown  = errdef.Define("internal") // want `kind "internal" is already defined at .*b.go:13:16`
+
+	UserID, UserIDFrom = errdef.DefineField[string]("user_id")
+	Count, CountFrom   = errdef.DefineField[string]("count") // want `field "count" is already defined with type int in package a at .*a.go:10:23`
+	Size, SizeFrom     = errdef.DefineField[int]("size")
+	Size2, Size2From   = errdef.DefineField[int64]("size") // want `field "size" is already defined with type int at .*b.go:18:23`
+)
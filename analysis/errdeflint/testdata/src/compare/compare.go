@@ -0,0 +1,36 @@
+package compare // want package:`definitions\(not_found\)`
+
+import (
+	"errors"
+
+	"github.com/shiwano/errdef"
+)
+
+var ErrNotFound = errdef.Define("not_found")
+
+func check(err error) bool {
+	if err == ErrNotFound { // want `comparison with a Definition using ==; use errors.Is instead`
+		return true
+	}
+	if ErrNotFound != err { // want `comparison with a Definition using !=; use errors.Is instead`
+		return false
+	}
+	switch err {
+	case ErrNotFound: // want `switch case compares with a Definition using ==; use errors.Is instead`
+		return true
+	case nil:
+		return false
+	}
+	if ErrNotFound == nil {
+		return false
+	}
+	return errors.Is(err, ErrNotFound)
+}
+
+func same(def errdef.Definition) bool {
+	return def == ErrNotFound
+}
+
+func sameFactory(f errdef.Factory) bool {
+	return f == ErrNotFound
+}
@@ -0,0 +1,21 @@
+package factory // want package:`definitions\(not_found\)`
+
+import (
+	"context"
+
+	"github.com/shiwano/errdef"
+)
+
+var (
+	ErrNotFound = errdef.Define("not_found")
+
+	notFoundFactory = ErrNotFound.With(context.Background()) // want `Factory notFoundFactory is stored in a package-level var; store the Definition and call With or WithOptions per error instead`
+
+	AnotherFactory errdef.Factory = ErrNotFound.WithOptions() // want `Factory AnotherFactory is stored in a package-level var; .*`
+)
+
+func newError(ctx context.Context) error {
+	f := ErrNotFound.With(ctx)
+	_ = notFoundFactory
+	return f.New("not found")
+}
@@ -0,0 +1,23 @@
+package scope // want package:`definitions\(ignored, ignored_inline, lazy, local, ok\)`
+
+import "github.com/shiwano/errdef"
+
+var ErrOK = errdef.Define("ok")
+
+var ErrLazy = func() errdef.Definition {
+	return errdef.Define("lazy") // want `errdef.Define should be called in a package-level var declaration`
+}()
+
+func newDefinition() errdef.Definition {
+	_, _ = errdef.DefineField[string]("local") // want `errdef.DefineField should be called in a package-level var declaration`
+	return errdef.Define("local")              // want `errdef.Define should be called in a package-level var declaration`
+}
+
+func ignored() errdef.Definition {
+	//errdeflint:ignore defined at runtime
+	return errdef.Define("ignored")
+}
+
+func ignoredInline() errdef.Definition {
+	return errdef.Define("ignored_inline") //errdeflint:ignore
+}
@@ -0,0 +1,11 @@
+package scopetest
+
+import (
+	"testing"
+
+	"github.com/shiwano/errdef"
+)
+
+func TestDefine(t *testing.T) {
+	_ = errdef.Define("test")
+}
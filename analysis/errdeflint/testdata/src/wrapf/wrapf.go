@@ -0,0 +1,21 @@
+package wrapf // want package:`definitions\(database\)`
+
+import (
+	"github.com/shiwano/errdef"
+)
+
+var ErrDatabase = errdef.Define("database")
+
+type repo struct{ err error }
+
+func wrap(err error, r repo, id string) []error {
+	return []error{
+		ErrDatabase.Wrapf(err, "failed to find user %s", id),
+		ErrDatabase.Wrapf(err, "failed to find user %s: %w", id, err), // want `Wrapf format contains %w; the cause is appended automatically`
+		ErrDatabase.Wrapf(err, "failed to find user %s: %s", id, err), // want `Wrapf is passed the cause as a format argument; .*`
+		ErrDatabase.Wrapf(err, "failed: %v", err.Error()),             // want `Wrapf is passed the cause as a format argument; .*`
+		ErrDatabase.Wrapf(r.err, "failed: %v", r.err),                 // want `Wrapf is passed the cause as a format argument; .*`
+		ErrDatabase.WithOptions().Wrapf(err, "failed: %v", err),       // want `Wrapf is passed the cause as a format argument; .*`
+		ErrDatabase.Wrapf(r.err, "failed: %v", err),
+	}
+}
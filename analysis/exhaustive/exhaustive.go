@@ -0,0 +1,378 @@
+// Package exhaustive defines an Analyzer that checks errdef.Match and
+// errdef.MatchValue chains for exhaustiveness.
+//
+// A set of definitions is declared by a package-level var initialized with
+// resolver.New and a literal list of definitions, named after the var:
+//
+//	var Resolver = resolver.New(ErrNotFound, ErrConflict)
+//
+// or by a package-level var block annotated with "//errdef:exhaustive". All the
+// definitions declared in the block belong to the set, which is named after the
+// annotation argument or, if omitted, the first var in the block:
+//
+//	//errdef:exhaustive DomainErrors
+//	var (
+//		ErrNotFound = errdef.Define("not_found")
+//		ErrConflict = errdef.Define("conflict")
+//	)
+//
+// A Match chain that has a case for any definition of a set must have cases for
+// all of its definitions, so that adding a new definition fails linting wherever
+// a handler forgot to map it. The chain must be written as a single expression.
+// A Default handler does not make the chain exhaustive unless the
+// -default-signifies-exhaustive flag is set.
+package exhaustive
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	errdefPkgPath   = "github.com/shiwano/errdef"
+	resolverPkgPath = "github.com/shiwano/errdef/resolver"
+
+	directive = "//errdef:exhaustive"
+)
+
+const doc = `check errdef.Match chains for exhaustiveness
+
+Reports Match and MatchValue chains that handle some, but not all, definitions
+of a set declared by resolver.New or a var block annotated with
+"//errdef:exhaustive".`
+
+// Analyzer checks errdef.Match and errdef.MatchValue chains for exhaustiveness.
+var Analyzer = &analysis.Analyzer{
+	Name:      "errdefexhaustive",
+	Doc:       doc,
+	URL:       "https://pkg.go.dev/github.com/shiwano/errdef/analysis/exhaustive",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(setsFact)},
+}
+
+var defaultSignifiesExhaustive bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&defaultSignifiesExhaustive, "default-signifies-exhaustive", false,
+		"treat chains with a Default handler as exhaustive")
+}
+
+type (
+	// setsFact records the definition sets declared in a package.
+	// Each set maps to the qualified names ("pkgpath.Name") of its definitions.
+	setsFact struct {
+		Sets map[string][]string
+	}
+
+	// matchChain is a Match or MatchValue call followed by Case and Default calls.
+	matchChain struct {
+		match      *ast.CallExpr
+		cases      []string
+		hasDefault bool
+	}
+)
+
+func (*setsFact) AFact() {}
+
+func (f *setsFact) String() string {
+	return "sets(" + strings.Join(slices.Sorted(maps.Keys(f.Sets)), ", ") + ")"
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	local := collectSets(pass)
+	if len(local) > 0 {
+		pass.ExportPackageFact(&setsFact{Sets: local})
+	}
+
+	sets := make(map[string][]string)
+	for _, pf := range pass.AllPackageFacts() {
+		if f, ok := pf.Fact.(*setsFact); ok {
+			for name, members := range f.Sets {
+				sets[pf.Package.Path()+"."+name] = members
+			}
+		}
+	}
+	for name, members := range local {
+		sets[pass.Pkg.Path()+"."+name] = members
+	}
+	if len(sets) == 0 {
+		return nil, nil
+	}
+
+	for _, chain := range collectChains(pass, ins) {
+		if chain.hasDefault && defaultSignifiesExhaustive {
+			continue
+		}
+		for _, setName := range slices.Sorted(maps.Keys(sets)) {
+			members := sets[setName]
+			if !containsAny(members, chain.cases) {
+				continue
+			}
+			var missing []string
+			for _, m := range members {
+				if !slices.Contains(chain.cases, m) {
+					missing = append(missing, displayName(m))
+				}
+			}
+			if len(missing) > 0 {
+				pass.Reportf(chain.match.Pos(), "missing cases for %s: %s", displayName(setName), strings.Join(missing, ", "))
+			}
+		}
+	}
+	return nil, nil
+}
+
+// collectSets returns the definition sets declared in the package.
+func collectSets(pass *analysis.Pass) map[string][]string {
+	def := lookupType(pass.Pkg, errdefPkgPath, "Definition")
+	if def == nil {
+		return nil
+	}
+	iface := def.Underlying().(*types.Interface)
+
+	sets := make(map[string][]string)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+
+			if name, ok := directiveName(gen.Doc); ok {
+				var members []string
+				for _, spec := range gen.Specs {
+					for _, ident := range spec.(*ast.ValueSpec).Names {
+						obj := pass.TypesInfo.Defs[ident]
+						if obj != nil && types.Implements(obj.Type(), iface) {
+							members = append(members, qualifiedName(obj))
+						}
+					}
+				}
+				if name == "" && len(members) > 0 {
+					name = gen.Specs[0].(*ast.ValueSpec).Names[0].Name
+				}
+				if name != "" {
+					sets[name] = members
+				}
+			}
+
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, v := range vs.Values {
+					if i >= len(vs.Names) {
+						break
+					}
+					if members, ok := resolverMembers(pass, v); ok {
+						sets[vs.Names[i].Name] = members
+					}
+				}
+			}
+		}
+	}
+	return sets
+}
+
+// resolverMembers returns the definitions passed to a resolver.New call in expr.
+func resolverMembers(pass *analysis.Pass, expr ast.Expr) ([]string, bool) {
+	var (
+		members []string
+		found   bool
+	)
+	ast.Inspect(expr, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || found || call.Ellipsis.IsValid() {
+			return !found
+		}
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != resolverPkgPath || fn.Name() != "New" {
+			return true
+		}
+		found = true
+		for _, arg := range call.Args {
+			if obj := packageVar(pass.TypesInfo, arg); obj != nil {
+				members = append(members, qualifiedName(obj))
+			}
+		}
+		return false
+	})
+	return members, found
+}
+
+// collectChains returns the Match chains written as single expressions.
+func collectChains(pass *analysis.Pass, ins *inspector.Inspector) []matchChain {
+	receivers := make(map[ast.Expr]bool)
+	var calls []*ast.CallExpr
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if name := matcherMethod(pass.TypesInfo, call); name != "" {
+			receivers[ast.Unparen(call.Fun.(*ast.SelectorExpr).X)] = true
+			calls = append(calls, call)
+		}
+	})
+
+	var chains []matchChain
+	for _, call := range calls {
+		if receivers[call] {
+			continue // not the outermost call of the chain
+		}
+		chain := matchChain{}
+		var expr ast.Expr = call
+		for {
+			c, ok := ast.Unparen(expr).(*ast.CallExpr)
+			if !ok {
+				break
+			}
+			if isMatchFunc(pass.TypesInfo, c) {
+				chain.match = c
+				break
+			}
+			switch matcherMethod(pass.TypesInfo, c) {
+			case "Case":
+				if len(c.Args) > 0 {
+					if obj := packageVar(pass.TypesInfo, c.Args[0]); obj != nil {
+						chain.cases = append(chain.cases, qualifiedName(obj))
+					}
+				}
+			case "Default":
+				chain.hasDefault = true
+			case "":
+				c = nil
+			}
+			if c == nil {
+				break
+			}
+			expr = c.Fun.(*ast.SelectorExpr).X
+		}
+		if chain.match != nil {
+			chains = append(chains, chain)
+		}
+	}
+	return chains
+}
+
+// matcherMethod returns the name of the Matcher or ValueMatcher method called by call.
+func matcherMethod(info *types.Info, call *ast.CallExpr) string {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != errdefPkgPath {
+		return ""
+	}
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return ""
+	}
+	t := recv.Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return ""
+	}
+	switch named.Obj().Name() {
+	case "Matcher", "ValueMatcher":
+		switch fn.Name() {
+		case "Case", "Default":
+			return fn.Name()
+		}
+	}
+	return ""
+}
+
+func isMatchFunc(info *types.Info, call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != errdefPkgPath || fn.Signature().Recv() != nil {
+		return false
+	}
+	return fn.Name() == "Match" || fn.Name() == "MatchValue"
+}
+
+// packageVar returns the package-level var referred to by expr, if any.
+func packageVar(info *types.Info, expr ast.Expr) types.Object {
+	var ident *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return nil
+	}
+	v, ok := info.Uses[ident].(*types.Var)
+	if !ok || v.Pkg() == nil || v.Parent() != v.Pkg().Scope() {
+		return nil
+	}
+	return v
+}
+
+func directiveName(doc *ast.CommentGroup) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+	for _, c := range doc.List {
+		if rest, ok := strings.CutPrefix(c.Text, directive); ok {
+			if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+				continue
+			}
+			return strings.TrimSpace(rest), true
+		}
+	}
+	return "", false
+}
+
+func lookupType(pkg *types.Package, pkgPath, name string) types.Type {
+	var find func(p *types.Package, visited map[*types.Package]bool) *types.Package
+	find = func(p *types.Package, visited map[*types.Package]bool) *types.Package {
+		if p.Path() == pkgPath {
+			return p
+		}
+		visited[p] = true
+		for _, imp := range p.Imports() {
+			if !visited[imp] {
+				if found := find(imp, visited); found != nil {
+					return found
+				}
+			}
+		}
+		return nil
+	}
+	p := find(pkg, make(map[*types.Package]bool))
+	if p == nil {
+		return nil
+	}
+	if obj, ok := p.Scope().Lookup(name).(*types.TypeName); ok {
+		return obj.Type()
+	}
+	return nil
+}
+
+func qualifiedName(obj types.Object) string {
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// displayName shortens a qualified name to "pkgname.Name".
+func displayName(qualified string) string {
+	i := strings.LastIndex(qualified, ".")
+	return path.Base(qualified[:i]) + qualified[i:]
+}
+
+func containsAny(s, values []string) bool {
+	for _, v := range values {
+		if slices.Contains(s, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,14 @@
+package exhaustive_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/shiwano/errdef/analysis/exhaustive"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, exhaustive.Analyzer, "errdefs", "handler")
+}
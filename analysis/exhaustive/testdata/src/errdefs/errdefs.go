@@ -0,0 +1,20 @@
+package errdefs // want package:`sets\(Domain, Resolver\)`
+
+import (
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/resolver"
+)
+
+//errdef:exhaustive Domain
+var (
+	ErrNotFound = errdef.Define("not_found")
+	ErrConflict = errdef.Define("conflict")
+)
+
+var (
+	ErrUnauthorized = errdef.Define("unauthorized")
+	ErrForbidden    = errdef.Define("forbidden")
+	ErrInternal     = errdef.Define("internal")
+)
+
+var Resolver = resolver.New(ErrUnauthorized, ErrForbidden).WithDefault(ErrInternal)
@@ -0,0 +1,12 @@
+// Package resolver is a stub of github.com/shiwano/errdef/resolver for analyzer tests.
+package resolver
+
+import "github.com/shiwano/errdef"
+
+type StrictResolver struct{}
+
+type DefaultResolver struct{}
+
+func New(defs ...errdef.Definition) *StrictResolver { return nil }
+
+func (r *StrictResolver) WithDefault(def errdef.Definition) *DefaultResolver { return nil }
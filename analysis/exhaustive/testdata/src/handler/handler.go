@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"errdefs"
+
+	"github.com/shiwano/errdef"
+)
+
+func handle(err error) {
+	m := errdef.Match(err) // chains stored in variables are not checked
+	m.Case(errdefs.ErrNotFound, func(err error) {})
+
+	errdef.Match(err).Case(errdefs.ErrNotFound, func(err error) {}).Default(func(err error) {}) // want `missing cases for errdefs.Domain: errdefs.ErrConflict`
+
+	errdef.Match(err).
+		Case(errdefs.ErrNotFound, func(err error) {}).
+		Case(errdefs.ErrConflict, func(err error) {})
+
+	errdef.Match(err).
+		Case(errdefs.ErrInternal, func(err error) {}).
+		Default(func(err error) {})
+}
+
+func status(err error) int {
+	return errdef.MatchValue[int](err). // want `missing cases for errdefs.Domain: errdefs.ErrConflict` `missing cases for errdefs.Resolver: errdefs.ErrForbidden`
+						Case(errdefs.ErrUnauthorized, func(err error) int { return 401 }).
+						Case(errdefs.ErrNotFound, func(err error) int { return 404 }).
+						Default(func(err error) int { return 500 })
+}
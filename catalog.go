@@ -0,0 +1,245 @@
+package errdef
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+)
+
+type (
+	// Catalog holds the definitions and fields loaded at runtime by LoadCatalog.
+	// It is useful for systems that learn error kinds at runtime, such as
+	// plugin hosts, to restore errors whose kinds are not compiled into the binary.
+	Catalog struct {
+		defs         []Definition
+		defsByKind   map[Kind]Definition
+		fields       []catalogField
+		fieldsByName map[string]int
+	}
+
+	catalogField struct {
+		key    FieldKey
+		option func(value any) (Option, bool)
+	}
+
+	catalogSpec struct {
+		Fields []catalogFieldSpec `json:"fields" yaml:"fields"`
+		Kinds  []catalogKindSpec  `json:"kinds" yaml:"kinds"`
+	}
+
+	catalogFieldSpec struct {
+		Name      string `json:"name" yaml:"name"`
+		Type      string `json:"type" yaml:"type"`
+		Sensitive bool   `json:"sensitive" yaml:"sensitive"`
+		Public    bool   `json:"public" yaml:"public"`
+	}
+
+	catalogKindSpec struct {
+		Kind       Kind   `json:"kind" yaml:"kind"`
+		HTTPStatus int    `json:"http_status" yaml:"http_status"`
+		Retryable  bool   `json:"retryable" yaml:"retryable"`
+		Public     bool   `json:"public" yaml:"public"`
+		LogLevel   string `json:"log_level" yaml:"log_level"`
+		HelpURL    string `json:"help_url" yaml:"help_url"`
+	}
+)
+
+// catalogFieldTypes maps the field types supported by catalogs to their field factories.
+var catalogFieldTypes = map[string]func(name string, opts []FieldOption) catalogField{
+	"string":        newCatalogField[string],
+	"bool":          newCatalogField[bool],
+	"int":           newCatalogField[int],
+	"int8":          newCatalogField[int8],
+	"int16":         newCatalogField[int16],
+	"int32":         newCatalogField[int32],
+	"int64":         newCatalogField[int64],
+	"uint":          newCatalogField[uint],
+	"uint8":         newCatalogField[uint8],
+	"uint16":        newCatalogField[uint16],
+	"uint32":        newCatalogField[uint32],
+	"uint64":        newCatalogField[uint64],
+	"float32":       newCatalogField[float32],
+	"float64":       newCatalogField[float64],
+	"[]string":      newCatalogField[[]string],
+	"time.Duration": newCatalogField[time.Duration],
+	"time.Time":     newCatalogField[time.Time],
+	"slog.Level":    newCatalogField[slog.Level],
+}
+
+// LoadCatalog reads a JSON catalog of error kinds and fields, and returns
+// the definitions and fields built from it.
+//
+// The catalog has the same format as the schema of errdefgen, so one file can
+// serve both generated and dynamic definitions. Keys that are only meaningful
+// for code generation (e.g., "go_name", "message") are ignored.
+//
+//	{
+//	  "fields": [
+//	    {"name": "user_id", "type": "string", "public": true}
+//	  ],
+//	  "kinds": [
+//	    {"kind": "not_found", "http_status": 404, "public": true, "log_level": "warn"}
+//	  ]
+//	}
+//
+// A kind can have http_status, retryable, public, log_level ("debug", "info",
+// "warn", or "error"), and help_url, which are applied as the corresponding
+// built-in options. A field type must be a Go primitive type (e.g., "string",
+// "int64", "float64", "bool"), "[]string", "time.Duration", "time.Time",
+// or "slog.Level".
+//
+// Use resolver.FromCatalog to build a resolver, and Catalog.FieldKeys with
+// unmarshaler.WithCustomFields to restore the fields.
+func LoadCatalog(r io.Reader) (*Catalog, error) {
+	return LoadCatalogFunc(r, json.Unmarshal)
+}
+
+// LoadCatalogFunc is like LoadCatalog, but decodes the catalog with the given
+// unmarshal function. It allows catalogs in other formats, such as YAML:
+//
+//	catalog, err := errdef.LoadCatalogFunc(r, yaml.Unmarshal)
+func LoadCatalogFunc(r io.Reader, unmarshal func(data []byte, v any) error) (*Catalog, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var spec catalogSpec
+	if err := unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("errdef: failed to decode catalog: %w", err)
+	}
+	return newCatalog(&spec)
+}
+
+func newCatalog(spec *catalogSpec) (*Catalog, error) {
+	c := &Catalog{
+		defsByKind:   make(map[Kind]Definition, len(spec.Kinds)),
+		fieldsByName: make(map[string]int, len(spec.Fields)),
+	}
+
+	for i, f := range spec.Fields {
+		if f.Name == "" {
+			return nil, fmt.Errorf("errdef: catalog fields[%d]: name is required", i)
+		}
+		if _, ok := c.fieldsByName[f.Name]; ok {
+			return nil, fmt.Errorf("errdef: catalog field %q is defined more than once", f.Name)
+		}
+		newField, ok := catalogFieldTypes[f.Type]
+		if !ok {
+			return nil, fmt.Errorf("errdef: catalog field %q has unsupported type %q", f.Name, f.Type)
+		}
+		var opts []FieldOption
+		if f.Sensitive {
+			opts = append(opts, Sensitive())
+		}
+		if f.Public {
+			opts = append(opts, PublicField())
+		}
+		c.fieldsByName[f.Name] = len(c.fields)
+		c.fields = append(c.fields, newField(f.Name, opts))
+	}
+
+	for i, k := range spec.Kinds {
+		if k.Kind == "" {
+			return nil, fmt.Errorf("errdef: catalog kinds[%d]: kind is required", i)
+		}
+		if _, ok := c.defsByKind[k.Kind]; ok {
+			return nil, fmt.Errorf("errdef: catalog kind %q is defined more than once", k.Kind)
+		}
+		opts, err := k.options()
+		if err != nil {
+			return nil, err
+		}
+		//errdeflint:ignore catalog definitions are created at runtime
+		def := Define(k.Kind, opts...)
+		c.defs = append(c.defs, def)
+		c.defsByKind[k.Kind] = def
+	}
+	return c, nil
+}
+
+func (k *catalogKindSpec) options() ([]Option, error) {
+	var opts []Option
+	if k.HTTPStatus != 0 {
+		if k.HTTPStatus < 100 || k.HTTPStatus > 599 {
+			return nil, fmt.Errorf("errdef: catalog kind %q has invalid http_status %d", k.Kind, k.HTTPStatus)
+		}
+		opts = append(opts, HTTPStatus(k.HTTPStatus))
+	}
+	if k.LogLevel != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(k.LogLevel)); err != nil {
+			return nil, fmt.Errorf("errdef: catalog kind %q has invalid log_level %q", k.Kind, k.LogLevel)
+		}
+		opts = append(opts, LogLevel(level))
+	}
+	if k.Public {
+		opts = append(opts, Public())
+	}
+	if k.Retryable {
+		opts = append(opts, Retryable())
+	}
+	if k.HelpURL != "" {
+		opts = append(opts, HelpURL(k.HelpURL))
+	}
+	return opts, nil
+}
+
+func newCatalogField[T any](name string, opts []FieldOption) catalogField {
+	//errdeflint:ignore catalog fields are created at runtime
+	ctor, _ := DefineField[T](name, opts...)
+	key := ctor.Key()
+	return catalogField{
+		key: key,
+		option: func(value any) (Option, bool) {
+			v, ok := key.NewValue(value)
+			if !ok {
+				return nil, false
+			}
+			return ctor(v.Value().(T)), true
+		},
+	}
+}
+
+// Definitions returns the definitions in the catalog, in the order they are declared.
+func (c *Catalog) Definitions() []Definition {
+	return c.defs
+}
+
+// Definition returns the definition of the given kind.
+// Returns false if the catalog has no such kind.
+func (c *Catalog) Definition(kind Kind) (Definition, bool) {
+	def, ok := c.defsByKind[kind]
+	return def, ok
+}
+
+// FieldKeys returns the keys of the fields in the catalog, in the order they are declared.
+func (c *Catalog) FieldKeys() []FieldKey {
+	keys := make([]FieldKey, len(c.fields))
+	for i, f := range c.fields {
+		keys[i] = f.key
+	}
+	return keys
+}
+
+// FieldKey returns the key of the field with the given name.
+// Returns false if the catalog has no such field.
+func (c *Catalog) FieldKey(name string) (FieldKey, bool) {
+	i, ok := c.fieldsByName[name]
+	if !ok {
+		return nil, false
+	}
+	return c.fields[i].key, true
+}
+
+// Field returns an Option that sets the field with the given name.
+// Returns false if the catalog has no such field, or the value does not have
+// the declared type of the field.
+func (c *Catalog) Field(name string, value any) (Option, bool) {
+	i, ok := c.fieldsByName[name]
+	if !ok {
+		return nil, false
+	}
+	return c.fields[i].option(value)
+}
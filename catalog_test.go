@@ -0,0 +1,165 @@
+package errdef_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiwano/errdef"
+)
+
+const testCatalogJSON = `{
+	"package": "ignored",
+	"fields": [
+		{"name": "user_id", "type": "string", "public": true},
+		{"name": "password", "type": "string", "sensitive": true},
+		{"name": "attempts", "type": "int"},
+		{"name": "timeout", "type": "time.Duration"}
+	],
+	"kinds": [
+		{
+			"kind": "not_found",
+			"http_status": 404,
+			"public": true,
+			"log_level": "warn",
+			"help_url": "https://example.com/errors/not_found",
+			"go_name": "ErrMissing"
+		},
+		{"kind": "unavailable", "http_status": 503, "retryable": true}
+	]
+}`
+
+func TestLoadCatalog(t *testing.T) {
+	c, err := errdef.LoadCatalog(strings.NewReader(testCatalogJSON))
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	t.Run("definitions", func(t *testing.T) {
+		defs := c.Definitions()
+		if len(defs) != 2 || defs[0].Kind() != "not_found" || defs[1].Kind() != "unavailable" {
+			t.Fatalf("want definitions [not_found unavailable], got %v", defs)
+		}
+
+		def, ok := c.Definition("not_found")
+		if !ok {
+			t.Fatal("want not_found to be found")
+		}
+		err := def.New("user not found")
+		if got, _ := errdef.HTTPStatusFrom(err); got != 404 {
+			t.Errorf("want http status 404, got %d", got)
+		}
+		if !errdef.IsPublic(err) {
+			t.Error("want public")
+		}
+		if got, _ := errdef.LogLevelFrom(err); got != slog.LevelWarn {
+			t.Errorf("want log level %v, got %v", slog.LevelWarn, got)
+		}
+		if got, _ := errdef.HelpURLFrom(err); got != "https://example.com/errors/not_found" {
+			t.Errorf("want help url, got %q", got)
+		}
+		if errdef.IsRetryable(err) {
+			t.Error("want not retryable")
+		}
+
+		unavailable, _ := c.Definition("unavailable")
+		if !errdef.IsRetryable(unavailable.New("unavailable")) {
+			t.Error("want retryable")
+		}
+
+		if _, ok := c.Definition("unknown"); ok {
+			t.Error("want unknown kind not to be found")
+		}
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		keys := c.FieldKeys()
+		if len(keys) != 4 || keys[0].String() != "user_id" || keys[3].String() != "timeout" {
+			t.Fatalf("want 4 field keys in order, got %v", keys)
+		}
+		if _, ok := c.FieldKey("unknown"); ok {
+			t.Error("want unknown field not to be found")
+		}
+
+		def, _ := c.Definition("not_found")
+		userID, ok := c.Field("user_id", "u123")
+		if !ok {
+			t.Fatal("want user_id option")
+		}
+		timeout, ok := c.Field("timeout", 3*time.Second)
+		if !ok {
+			t.Fatal("want timeout option")
+		}
+		password, _ := c.Field("password", "secret")
+		err := def.With(t.Context(), userID, timeout, password).New("user not found")
+
+		var e errdef.Error
+		if !errors.As(err, &e) {
+			t.Fatal("want errdef.Error")
+		}
+		key, _ := c.FieldKey("timeout")
+		if v, ok := e.Fields().Get(key); !ok || v.Value() != 3*time.Second {
+			t.Errorf("want timeout 3s, got %v", v)
+		}
+
+		data, _ := json.Marshal(err)
+		if !strings.Contains(string(data), `"user_id":"u123"`) {
+			t.Errorf("want user_id in JSON, got %s", data)
+		}
+		if strings.Contains(string(data), "secret") {
+			t.Errorf("want password redacted, got %s", data)
+		}
+
+		view, _ := errdef.PublicView(err)
+		if view.Fields["user_id"] != "u123" {
+			t.Errorf("want user_id to be public, got %v", view.Fields)
+		}
+
+		if _, ok := c.Field("attempts", "three"); ok {
+			t.Error("want mismatched value type to be rejected")
+		}
+		if _, ok := c.Field("unknown", 1); ok {
+			t.Error("want unknown field to be rejected")
+		}
+	})
+
+	t.Run("unmarshal func", func(t *testing.T) {
+		c, err := errdef.LoadCatalogFunc(strings.NewReader(testCatalogJSON), func(data []byte, v any) error {
+			return json.Unmarshal(data, v)
+		})
+		if err != nil {
+			t.Fatalf("want no error, got %v", err)
+		}
+		if len(c.Definitions()) != 2 {
+			t.Errorf("want 2 definitions, got %d", len(c.Definitions()))
+		}
+	})
+
+	t.Run("invalid catalogs", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			catalog string
+			want    string
+		}{
+			{"malformed", `{`, "failed to decode catalog"},
+			{"missing kind", `{"kinds": [{"http_status": 404}]}`, "kinds[0]: kind is required"},
+			{"duplicate kind", `{"kinds": [{"kind": "a"}, {"kind": "a"}]}`, `kind "a" is defined more than once`},
+			{"invalid status", `{"kinds": [{"kind": "a", "http_status": 42}]}`, "invalid http_status 42"},
+			{"invalid log level", `{"kinds": [{"kind": "a", "log_level": "fatal"}]}`, `invalid log_level "fatal"`},
+			{"missing field name", `{"fields": [{"type": "string"}]}`, "fields[0]: name is required"},
+			{"duplicate field", `{"fields": [{"name": "a", "type": "int"}, {"name": "a", "type": "int"}]}`, `field "a" is defined more than once`},
+			{"unsupported type", `{"fields": [{"name": "a", "type": "netip.Addr"}]}`, `unsupported type "netip.Addr"`},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := errdef.LoadCatalog(strings.NewReader(tt.catalog))
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("want error containing %q, got %v", tt.want, err)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,63 @@
+// Package cbor provides a dependency-free CBOR (RFC 8949) encoding for errdef errors.
+//
+// An error is encoded as a map with the same keys as its JSON encoding
+// (message, kind, type, fields, stack, and causes), but field values keep
+// their types: integers are decoded as int64 (or uint64 if they do not fit),
+// floats as float64, byte strings as []byte, and time.Time values, encoded
+// as tagged date/time strings, as time.Time. This avoids the float64
+// conversions of JSON described in unmarshaler.DecodedData.
+//
+//	m := cbor.NewMarshaler()
+//	data, err := m.Marshal(err)
+//
+//	u := cbor.NewUnmarshaler(resolver)
+//	restored, err := u.Unmarshal(data)
+package cbor
+
+import (
+	"fmt"
+
+	"github.com/shiwano/errdef/internal/wire"
+	"github.com/shiwano/errdef/marshaler"
+	"github.com/shiwano/errdef/resolver"
+	"github.com/shiwano/errdef/unmarshaler"
+)
+
+var (
+	_ marshaler.Encoder[[]byte]   = Encode
+	_ unmarshaler.Decoder[[]byte] = Decode
+)
+
+// NewMarshaler creates a new Marshaler that marshals errors into CBOR.
+func NewMarshaler(opts ...marshaler.Option) *marshaler.Marshaler[[]byte] {
+	return marshaler.New(Encode, opts...)
+}
+
+// NewUnmarshaler creates a new Unmarshaler that unmarshals errors from CBOR.
+func NewUnmarshaler(r resolver.Resolver, opts ...unmarshaler.Option) *unmarshaler.Unmarshaler[[]byte] {
+	return unmarshaler.New(r, Decode, opts...)
+}
+
+// Encode encodes the data of an error into CBOR.
+// It returns an error if a field value cannot be encoded (e.g., a channel).
+func Encode(data *marshaler.EncodedData) ([]byte, error) {
+	var w writer
+	if err := wire.WriteData(&w, data); err != nil {
+		return nil, fmt.Errorf("cbor: %w", err)
+	}
+	return w.buf, nil
+}
+
+// Decode decodes CBOR data into the data of an error.
+// Both definite and indefinite lengths are accepted.
+func Decode(data []byte) (*unmarshaler.DecodedData, error) {
+	r := reader{data: data}
+	decoded, err := wire.ReadData(&r)
+	if err != nil {
+		return nil, fmt.Errorf("cbor: %w", err)
+	}
+	if r.pos != len(r.data) {
+		return nil, fmt.Errorf("cbor: %d trailing bytes", len(r.data)-r.pos)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,251 @@
+package cbor_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/cbor"
+	"github.com/shiwano/errdef/marshaler"
+	"github.com/shiwano/errdef/resolver"
+	"github.com/shiwano/errdef/unmarshaler"
+)
+
+type address struct {
+	City string `json:"city"`
+}
+
+var (
+	errNotFound = errdef.Define("not_found", errdef.HTTPStatus(404), errdef.LogLevel(slog.LevelWarn))
+	errInternal = errdef.Define("internal")
+
+	count, countFrom       = errdef.DefineField[int64]("count")
+	big, bigFrom           = errdef.DefineField[uint64]("big")
+	ratio, ratioFrom       = errdef.DefineField[float32]("ratio")
+	timeout, timeoutFrom   = errdef.DefineField[time.Duration]("timeout")
+	at, atFrom             = errdef.DefineField[time.Time]("at")
+	tags, tagsFrom         = errdef.DefineField[[]string]("tags")
+	addr, addrFrom         = errdef.DefineField[address]("addr")
+	payload, payloadFrom   = errdef.DefineField[[]byte]("payload")
+	password, passwordFrom = errdef.DefineField[string]("password", errdef.Sensitive())
+)
+
+func TestRoundTrip(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	orig := errNotFound.WithOptions(
+		count(math.MaxInt64),
+		big(math.MaxUint64),
+		ratio(0.5),
+		timeout(2*time.Second),
+		at(now),
+		tags([]string{"a", "b"}),
+		addr(address{City: "Tokyo"}),
+		payload([]byte{0, 1, 2}),
+		password("secret"),
+	).Wrapf(errInternal.Wrap(io.EOF), "user not found").(errdef.Error)
+
+	data, err := cbor.NewMarshaler().Marshal(orig)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	r := resolver.New(errNotFound, errInternal)
+	u := cbor.NewUnmarshaler(r,
+		unmarshaler.WithBuiltinFields(),
+		unmarshaler.WithStandardSentinelErrors(),
+		unmarshaler.WithCustomFields(
+			count.Key(), big.Key(), ratio.Key(), timeout.Key(), at.Key(),
+			tags.Key(), addr.Key(), payload.Key(), password.Key(),
+		),
+	)
+	restored, err := u.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if restored.Error() != orig.Error() {
+		t.Errorf("want message %q, got %q", orig.Error(), restored.Error())
+	}
+	if restored.Kind() != "not_found" {
+		t.Errorf("want kind %q, got %q", "not_found", restored.Kind())
+	}
+	if !reflect.DeepEqual(restored.Stack().Frames(), orig.Stack().Frames()) {
+		t.Errorf("want stack %v, got %v", orig.Stack().Frames(), restored.Stack().Frames())
+	}
+	if !errors.Is(restored, io.EOF) {
+		t.Error("want restored error to wrap io.EOF")
+	}
+
+	check := func(name string, got, want any, ok bool) {
+		t.Helper()
+		if !ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: want %v, got %v (ok=%v)", name, want, got, ok)
+		}
+	}
+	status, ok := errdef.HTTPStatusFrom(restored)
+	check("http_status", status, 404, ok)
+	level, ok := errdef.LogLevelFrom(restored)
+	check("log_level", level, slog.LevelWarn, ok)
+	v1, ok := countFrom(restored)
+	check("count", v1, int64(math.MaxInt64), ok)
+	v2, ok := bigFrom(restored)
+	check("big", v2, uint64(math.MaxUint64), ok)
+	v3, ok := ratioFrom(restored)
+	check("ratio", v3, float32(0.5), ok)
+	v4, ok := timeoutFrom(restored)
+	check("timeout", v4, 2*time.Second, ok)
+	v5, ok := atFrom(restored)
+	check("at", v5, now, ok)
+	v6, ok := tagsFrom(restored)
+	check("tags", v6, []string{"a", "b"}, ok)
+	v7, ok := addrFrom(restored)
+	check("addr", v7, address{City: "Tokyo"}, ok)
+	v8, ok := payloadFrom(restored)
+	check("payload", v8, []byte{0, 1, 2}, ok)
+
+	if _, ok := passwordFrom(restored); ok {
+		t.Error("want redacted password to be unavailable")
+	}
+
+	again, err := cbor.NewMarshaler().Marshal(restored)
+	if err != nil {
+		t.Fatalf("failed to marshal restored error: %v", err)
+	}
+	if !bytes.Equal(again, data) {
+		t.Errorf("want stable round trip\nwant: %x\ngot:  %x", data, again)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("integer types", func(t *testing.T) {
+		data, err := cbor.Encode(&marshaler.EncodedData{
+			Message: "m",
+			Fields: map[string]any{
+				"fixint": 1,
+				"neg":    int8(-100),
+				"int32":  int32(math.MinInt32),
+				"uint16": uint16(math.MaxUint16),
+				"uint64": uint64(math.MaxUint64),
+				"float":  1.5,
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to encode: %v", err)
+		}
+		decoded, err := cbor.Decode(data)
+		if err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		want := map[string]any{
+			"fixint": int64(1),
+			"neg":    int64(-100),
+			"int32":  int64(math.MinInt32),
+			"uint16": int64(math.MaxUint16),
+			"uint64": uint64(math.MaxUint64),
+			"float":  1.5,
+		}
+		if !reflect.DeepEqual(decoded.Fields, want) {
+			t.Errorf("want %v, got %v", want, decoded.Fields)
+		}
+	})
+
+	t.Run("timestamps", func(t *testing.T) {
+		tm := time.Date(2025, 1, 2, 3, 4, 5, 123456789, time.UTC)
+		data, _ := cbor.Encode(&marshaler.EncodedData{Fields: map[string]any{"t": tm}})
+		decoded, err := cbor.Decode(data)
+		if err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		if got := decoded.Fields["t"]; got != tm {
+			t.Errorf("want %v, got %v", tm, got)
+		}
+	})
+
+	t.Run("spec encoding", func(t *testing.T) {
+		// Examples from RFC 8949 Appendix A in an indefinite-length map of fields.
+		var data []byte
+		data = append(data, 0xa2, 0x67)
+		data = append(data, "message"...)
+		data = append(data, 0x7f, 0x62, 'h', 'e', 0x63, 'l', 'l', 'o', 0xff) // (_ "he", "llo")
+		data = append(data, 0x66)
+		data = append(data, "fields"...)
+		data = append(data, 0xbf)
+		data = append(data, 0x61, 'a', 0xf9, 0x3c, 0x00)                   // 1.0 (half)
+		data = append(data, 0x61, 'b', 0xf9, 0xc4, 0x00)                   // -4.0 (half)
+		data = append(data, 0x61, 'c', 0x38, 0x63)                         // -100
+		data = append(data, 0x61, 'd', 0xc1, 0x1a, 0x51, 0x4b, 0x67, 0xb0) // 1(1363896240)
+		data = append(data, 0x61, 'e', 0x9f, 0x01, 0x82, 0x02, 0x03, 0xff) // [_ 1, [2, 3]]
+		data = append(data, 0x61, 'f', 0xd9, 0xd9, 0xf7, 0xf6)             // 55799(null)
+		data = append(data, 0xff)
+
+		decoded, err := cbor.Decode(data)
+		if err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		if decoded.Message != "hello" {
+			t.Errorf("want message %q, got %q", "hello", decoded.Message)
+		}
+		want := map[string]any{
+			"a": 1.0,
+			"b": -4.0,
+			"c": int64(-100),
+			"d": time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC),
+			"e": []any{int64(1), []any{int64(2), int64(3)}},
+			"f": nil,
+		}
+		if !reflect.DeepEqual(decoded.Fields, want) {
+			t.Errorf("want %v, got %v", want, decoded.Fields)
+		}
+	})
+
+	// field encodes {"fields": {"f": v}}.
+	field := func(v ...byte) []byte {
+		return append([]byte{0xa1, 0x66, 'f', 'i', 'e', 'l', 'd', 's', 0xa1, 0x61, 'f'}, v...)
+	}
+	// {"causes": [...]} nested beyond the maximum depth.
+	deepCauses := bytes.Repeat([]byte{0xa1, 0x66, 'c', 'a', 'u', 's', 'e', 's', 0x81}, 1100)
+
+	errorTests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "empty", data: nil, want: "unexpected end of data"},
+		{name: "truncated", data: []byte{0xa1, 0x67, 'm'}, want: "unexpected end of data"},
+		{name: "trailing", data: []byte{0xa0, 0xf6}, want: "1 trailing bytes"},
+		{name: "not a map", data: []byte{0x01}, want: "want map, got initial byte 0x01 at offset 0"},
+		{name: "break for map", data: []byte{0xff}, want: "want map, got initial byte 0xff at offset 0"},
+		{name: "non-string key", data: []byte{0xa1, 0x01, 0x01}, want: "want string, got initial byte 0x01 at offset 1"},
+		{name: "wrong message type", data: []byte{0xa1, 0x67, 'm', 'e', 's', 's', 'a', 'g', 'e', 0x01}, want: "message: want string"},
+		{name: "huge map", data: []byte{0xba, 0xff, 0xff, 0xff, 0xff}, want: "unexpected end of data"},
+		{name: "deep causes", data: deepCauses, want: "nesting depth exceeds"},
+		{name: "invalid initial byte", data: field(0x1c), want: "invalid initial byte 0x1c"},
+		{name: "unexpected break", data: field(0xff), want: "unexpected break"},
+		{name: "non-string field key", data: field(0xa1, 0x01, 0x01), want: "unsupported map key"},
+		{name: "negative overflow", data: field(0x3b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff), want: "overflows int64"},
+		{name: "huge array", data: field(0x9a, 0xff, 0xff, 0xff, 0xff), want: "unexpected end of data"},
+		{name: "deep nesting", data: field(bytes.Repeat([]byte{0x81}, 2000)...), want: "nesting depth exceeds"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := cbor.Decode(tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("want error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestEncode_UnsupportedType(t *testing.T) {
+	_, err := cbor.Encode(&marshaler.EncodedData{Fields: map[string]any{"ch": make(chan int)}})
+	if err == nil || !strings.Contains(err.Error(), `field "ch": unsupported type chan int`) {
+		t.Errorf("want unsupported type error, got %v", err)
+	}
+}
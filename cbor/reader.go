@@ -0,0 +1,386 @@
+package cbor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shiwano/errdef/internal/wire"
+)
+
+// reader reads values in the CBOR format into Go values:
+// nil, bool, int64, uint64, float64, string, []byte, time.Time, []any, and
+// map[string]any.
+type reader struct {
+	data []byte
+	pos  int
+}
+
+// tagEpochDateTime is the tag of date/times in seconds since the epoch.
+const tagEpochDateTime = 1
+
+// infoIndefinite is the additional information of items with indefinite lengths.
+const infoIndefinite = 31
+
+var (
+	errUnexpectedEOF = errors.New("unexpected end of data")
+	errBreak         = errors.New("unexpected break")
+)
+
+var _ wire.Reader = (*reader)(nil)
+
+func (r *reader) ReadValue() (any, error) {
+	return r.readValue(0)
+}
+
+func (r *reader) ReadNil() (bool, error) {
+	return r.skipByte(majorSimple|22, majorSimple|23)
+}
+
+func (r *reader) ReadBreak() (bool, error) {
+	return r.skipByte(majorSimple|infoIndefinite, majorSimple|infoIndefinite)
+}
+
+func (r *reader) ReadMapHeader() (int, error) {
+	n, indefinite, err := r.readHeadOf(majorMap, "map")
+	if err != nil || indefinite {
+		return -1, err
+	}
+	if n > uint64(len(r.data)-r.pos)/2 {
+		return 0, errUnexpectedEOF // each entry takes at least two bytes
+	}
+	return int(n), nil
+}
+
+func (r *reader) ReadArrayHeader() (int, error) {
+	n, indefinite, err := r.readHeadOf(majorArray, "array")
+	if err != nil || indefinite {
+		return -1, err
+	}
+	if n > uint64(len(r.data)-r.pos) {
+		return 0, errUnexpectedEOF // each element takes at least one byte
+	}
+	return int(n), nil
+}
+
+func (r *reader) ReadStringBytes() ([]byte, error) {
+	n, indefinite, err := r.readHeadOf(majorText, "string")
+	if err != nil {
+		return nil, err
+	}
+	if indefinite {
+		return r.readChunks(majorText, 0, true)
+	}
+	return r.readN(n)
+}
+
+func (r *reader) ReadInt() (int64, error) {
+	start := r.pos
+	major, _, arg, err := r.readHead()
+	if err != nil {
+		return 0, err
+	}
+
+	switch major {
+	case majorUint:
+		if arg > math.MaxInt64 {
+			return 0, fmt.Errorf("integer %d overflows int64", arg)
+		}
+		return int64(arg), nil
+	case majorNegInt:
+		if arg > math.MaxInt64 {
+			return 0, fmt.Errorf("negative integer -1-%d overflows int64", arg)
+		}
+		return -1 - int64(arg), nil
+	}
+	return 0, typeError("integer", r.data[start], start)
+}
+
+// readHeadOf reads the head of a data item of the given major type and
+// returns its argument, or reports that its length is indefinite.
+func (r *reader) readHeadOf(want byte, name string) (uint64, bool, error) {
+	start := r.pos
+	major, info, arg, err := r.readHead()
+	if errors.Is(err, errBreak) {
+		return 0, false, typeError(name, r.data[start], start)
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if major != want {
+		return 0, false, typeError(name, r.data[start], start)
+	}
+	return arg, info == infoIndefinite, nil
+}
+
+// skipByte reports whether the next byte is a or b, reading it if so.
+func (r *reader) skipByte(a, b byte) (bool, error) {
+	if r.pos >= len(r.data) {
+		return false, errUnexpectedEOF
+	}
+	if c := r.data[r.pos]; c != a && c != b {
+		return false, nil
+	}
+	r.pos++
+	return true, nil
+}
+
+func typeError(want string, b byte, offset int) error {
+	return fmt.Errorf("want %s, got initial byte 0x%02x at offset %d", want, b, offset)
+}
+
+func (r *reader) readValue(depth int) (any, error) {
+	if depth > wire.MaxDepth {
+		return nil, fmt.Errorf("nesting depth exceeds %d", wire.MaxDepth)
+	}
+
+	major, info, arg, err := r.readHead()
+	if err != nil {
+		return nil, err
+	}
+	indefinite := info == infoIndefinite
+
+	switch major {
+	case majorUint:
+		if arg > math.MaxInt64 {
+			return arg, nil
+		}
+		return int64(arg), nil
+	case majorNegInt:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("negative integer -1-%d overflows int64", arg)
+		}
+		return -1 - int64(arg), nil
+	case majorBytes:
+		data, err := r.readChunks(majorBytes, arg, indefinite)
+		if err != nil {
+			return nil, err
+		}
+		return data, nil
+	case majorText:
+		data, err := r.readChunks(majorText, arg, indefinite)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	case majorArray:
+		return r.readArray(arg, indefinite, depth)
+	case majorMap:
+		return r.readMap(arg, indefinite, depth)
+	case majorTag:
+		return r.readTag(arg, depth)
+	}
+	return readSimple(info, arg)
+}
+
+// readHead reads the initial byte of a data item, which consists of the major
+// type and the additional information, and the argument that follows it.
+// The argument of floats is their bits.
+func (r *reader) readHead() (major, info byte, arg uint64, err error) {
+	b, err := r.readByte()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, info = b&0xe0, b&0x1f
+
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info <= 27:
+		data, err := r.readN(1 << (info - 24))
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		for _, b := range data {
+			arg = arg<<8 | uint64(b)
+		}
+		return major, info, arg, nil
+	case info == infoIndefinite:
+		switch major {
+		case majorBytes, majorText, majorArray, majorMap:
+			return major, info, 0, nil
+		case majorSimple:
+			return 0, 0, 0, errBreak
+		}
+	}
+	return 0, 0, 0, fmt.Errorf("invalid initial byte 0x%02x", b)
+}
+
+// readChunks reads a byte or text string, concatenating the chunks of
+// strings with indefinite lengths.
+func (r *reader) readChunks(major byte, n uint64, indefinite bool) ([]byte, error) {
+	if !indefinite {
+		data, err := r.readN(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), data...), nil
+	}
+
+	var buf []byte
+	for {
+		m, info, arg, err := r.readHead()
+		if errors.Is(err, errBreak) {
+			return buf, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if m != major || info == infoIndefinite {
+			return nil, errors.New("invalid chunk of indefinite-length string")
+		}
+		data, err := r.readN(arg)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, data...)
+	}
+}
+
+func (r *reader) readArray(n uint64, indefinite bool, depth int) ([]any, error) {
+	if indefinite {
+		var values []any
+		for {
+			v, err := r.readValue(depth + 1)
+			if errors.Is(err, errBreak) {
+				if values == nil {
+					values = []any{}
+				}
+				return values, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+	}
+
+	if n > uint64(len(r.data)-r.pos) {
+		return nil, errUnexpectedEOF // each element takes at least one byte
+	}
+	values := make([]any, n)
+	for i := range values {
+		v, err := r.readValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func (r *reader) readMap(n uint64, indefinite bool, depth int) (map[string]any, error) {
+	if !indefinite && n > uint64(len(r.data)-r.pos)/2 {
+		return nil, errUnexpectedEOF // each entry takes at least two bytes
+	}
+
+	m := make(map[string]any)
+	for i := uint64(0); indefinite || i < n; i++ {
+		k, err := r.readValue(depth + 1)
+		if indefinite && errors.Is(err, errBreak) {
+			return m, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("unsupported map key of type %T", k)
+		}
+		v, err := r.readValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+	}
+	return m, nil
+}
+
+// readTag reads a tagged value. Date/time tags are decoded as time.Time;
+// the other tags are ignored and their contents are returned.
+func (r *reader) readTag(tag uint64, depth int) (any, error) {
+	v, err := r.readValue(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case tagDateTime:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("want string for date/time, got %T", v)
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case tagEpochDateTime:
+		switch v := v.(type) {
+		case int64:
+			return time.Unix(v, 0).UTC(), nil
+		case float64:
+			sec, frac := math.Modf(v)
+			return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+		}
+		return nil, fmt.Errorf("want number for epoch date/time, got %T", v)
+	}
+	return v, nil
+}
+
+func readSimple(info byte, arg uint64) (any, error) {
+	switch info {
+	case 25:
+		return halfToFloat64(uint16(arg)), nil
+	case 26:
+		return float64(math.Float32frombits(uint32(arg))), nil
+	case 27:
+		return math.Float64frombits(arg), nil
+	}
+
+	switch arg {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23: // null, undefined
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unsupported simple value %d", arg)
+}
+
+func (r *reader) readByte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errUnexpectedEOF
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *reader) readN(n uint64) ([]byte, error) {
+	if n > uint64(len(r.data)-r.pos) {
+		return nil, errUnexpectedEOF
+	}
+	data := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return data, nil
+}
+
+// halfToFloat64 converts an IEEE 754 half-precision float into float64.
+func halfToFloat64(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exp := int(h >> 10 & 0x1f)
+	frac := float64(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	}
+	return sign * math.Ldexp(frac+1024, exp-25)
+}
@@ -0,0 +1,104 @@
+package cbor
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/shiwano/errdef/internal/wire"
+)
+
+// Major types of CBOR data items.
+const (
+	majorUint   = 0 << 5
+	majorNegInt = 1 << 5
+	majorBytes  = 2 << 5
+	majorText   = 3 << 5
+	majorArray  = 4 << 5
+	majorMap    = 5 << 5
+	majorTag    = 6 << 5
+	majorSimple = 7 << 5
+)
+
+// tagDateTime is the tag of RFC 3339 date/time strings.
+const tagDateTime = 0
+
+// writer writes values in the CBOR format with definite lengths, using the
+// smallest representation of each value.
+type writer struct {
+	buf []byte
+}
+
+var _ wire.Writer = (*writer)(nil)
+
+func (w *writer) WriteNil() {
+	w.buf = append(w.buf, majorSimple|22)
+}
+
+func (w *writer) WriteBool(v bool) {
+	if v {
+		w.buf = append(w.buf, majorSimple|21)
+	} else {
+		w.buf = append(w.buf, majorSimple|20)
+	}
+}
+
+func (w *writer) WriteInt(v int64) {
+	if v >= 0 {
+		w.writeHead(majorUint, uint64(v))
+	} else {
+		w.writeHead(majorNegInt, uint64(-1-v))
+	}
+}
+
+func (w *writer) WriteUint(v uint64) {
+	w.writeHead(majorUint, v)
+}
+
+func (w *writer) WriteFloat32(v float32) {
+	w.buf = binary.BigEndian.AppendUint32(append(w.buf, majorSimple|26), math.Float32bits(v))
+}
+
+func (w *writer) WriteFloat64(v float64) {
+	w.buf = binary.BigEndian.AppendUint64(append(w.buf, majorSimple|27), math.Float64bits(v))
+}
+
+func (w *writer) WriteString(v string) {
+	w.writeHead(majorText, uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+func (w *writer) WriteBytes(v []byte) {
+	w.writeHead(majorBytes, uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+// WriteTime writes v as a tagged RFC 3339 string, which keeps nanoseconds.
+func (w *writer) WriteTime(v time.Time) {
+	w.writeHead(majorTag, tagDateTime)
+	w.WriteString(v.Format(time.RFC3339Nano))
+}
+
+func (w *writer) WriteArrayHeader(n int) {
+	w.writeHead(majorArray, uint64(n))
+}
+
+func (w *writer) WriteMapHeader(n int) {
+	w.writeHead(majorMap, uint64(n))
+}
+
+// writeHead writes the initial byte of a data item with its argument.
+func (w *writer) writeHead(major byte, v uint64) {
+	switch {
+	case v < 24:
+		w.buf = append(w.buf, major|byte(v))
+	case v <= math.MaxUint8:
+		w.buf = append(w.buf, major|24, byte(v))
+	case v <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, major|25), uint16(v))
+	case v <= math.MaxUint32:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, major|26), uint32(v))
+	default:
+		w.buf = binary.BigEndian.AppendUint64(append(w.buf, major|27), v)
+	}
+}
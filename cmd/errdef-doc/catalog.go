@@ -0,0 +1,315 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const errdefPkgPath = "github.com/shiwano/errdef"
+
+type (
+	// Catalog is the set of error definitions and fields found in the analyzed packages.
+	Catalog struct {
+		Definitions []DefinitionDoc `json:"definitions"`
+		Fields      []FieldDoc      `json:"fields"`
+	}
+
+	// DefinitionDoc describes an errdef.Define call.
+	DefinitionDoc struct {
+		Kind     string      `json:"kind"`
+		Name     string      `json:"name,omitempty"`
+		Package  string      `json:"package"`
+		Doc      string      `json:"doc,omitempty"`
+		Position string      `json:"position"`
+		Options  []OptionDoc `json:"options,omitempty"`
+	}
+
+	// FieldDoc describes an errdef.DefineField call.
+	FieldDoc struct {
+		Name        string      `json:"name"`
+		Type        string      `json:"type"`
+		Constructor string      `json:"constructor,omitempty"`
+		Extractor   string      `json:"extractor,omitempty"`
+		Package     string      `json:"package"`
+		Doc         string      `json:"doc,omitempty"`
+		Position    string      `json:"position"`
+		Options     []OptionDoc `json:"options,omitempty"`
+	}
+
+	// OptionDoc describes an option passed to Define or DefineField.
+	OptionDoc struct {
+		// Name is the option name, qualified with the package name if it is
+		// declared outside the errdef package (e.g., "HTTPStatus", "errdefs.UserID").
+		Name string `json:"name"`
+		// Args holds the argument values. Constant arguments are represented
+		// as JSON-compatible values, and others as their source expressions.
+		Args []any `json:"args,omitempty"`
+	}
+
+	// declInfo is the variable declaration that a call is assigned to.
+	declInfo struct {
+		names []string
+		doc   string
+	}
+)
+
+// LoadCatalog loads the packages matching the given patterns and
+// extracts the error catalog from them.
+func LoadCatalog(dir string, patterns ...string) (*Catalog, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir: dir,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return nil, fmt.Errorf("failed to load packages: %d error(s)", n)
+	}
+
+	c := &Catalog{}
+	for _, pkg := range pkgs {
+		c.addPackage(pkg, dir)
+	}
+
+	slices.SortStableFunc(c.Definitions, func(a, b DefinitionDoc) int {
+		return cmp.Compare(a.Package, b.Package)
+	})
+	slices.SortStableFunc(c.Fields, func(a, b FieldDoc) int {
+		return cmp.Compare(a.Package, b.Package)
+	})
+	return c, nil
+}
+
+func (c *Catalog) addPackage(pkg *packages.Package, dir string) {
+	for _, file := range pkg.Syntax {
+		decls := collectDecls(file)
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fn, ok := typeutil.Callee(pkg.TypesInfo, call).(*types.Func)
+			if !ok || fn.Pkg() == nil || fn.Pkg().Path() != errdefPkgPath || len(call.Args) == 0 {
+				return true
+			}
+
+			name, ok := constantString(pkg.TypesInfo, call.Args[0])
+			if !ok {
+				return true
+			}
+			decl := decls[call]
+			pos := position(pkg.Fset, call.Pos(), dir)
+
+			switch fn.Name() {
+			case "Define":
+				c.Definitions = append(c.Definitions, DefinitionDoc{
+					Kind:     name,
+					Name:     decl.name(0),
+					Package:  pkg.PkgPath,
+					Doc:      decl.doc,
+					Position: pos,
+					Options:  describeOptions(pkg, call.Args[1:]),
+				})
+			case "DefineField":
+				c.Fields = append(c.Fields, FieldDoc{
+					Name:        name,
+					Type:        fieldType(pkg, call),
+					Constructor: decl.name(0),
+					Extractor:   decl.name(1),
+					Package:     pkg.PkgPath,
+					Doc:         decl.doc,
+					Position:    pos,
+					Options:     describeOptions(pkg, call.Args[1:]),
+				})
+			}
+			return true
+		})
+	}
+}
+
+func (d declInfo) name(i int) string {
+	if i < len(d.names) && d.names[i] != "_" {
+		return d.names[i]
+	}
+	return ""
+}
+
+// collectDecls maps each call expression in package-level var specs
+// to the names and doc comment of its declaration.
+func collectDecls(file *ast.File) map[*ast.CallExpr]declInfo {
+	decls := make(map[*ast.CallExpr]declInfo)
+	for _, d := range file.Decls {
+		gen, ok := d.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			doc := vs.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			var names []string
+			for _, n := range vs.Names {
+				names = append(names, n.Name)
+			}
+			info := declInfo{names: names, doc: strings.TrimSpace(doc.Text())}
+
+			switch {
+			case len(vs.Values) == 1 && len(vs.Names) == 2:
+				// e.g., UserID, UserIDFrom = errdef.DefineField[string]("user_id")
+				if call, ok := astutil.Unparen(vs.Values[0]).(*ast.CallExpr); ok {
+					decls[call] = info
+				}
+			default:
+				for i, v := range vs.Values {
+					if call, ok := astutil.Unparen(v).(*ast.CallExpr); ok && i < len(names) {
+						decls[call] = declInfo{names: names[i : i+1], doc: info.doc}
+					}
+				}
+			}
+		}
+	}
+	return decls
+}
+
+func describeOptions(pkg *packages.Package, args []ast.Expr) []OptionDoc {
+	var opts []OptionDoc
+	for _, arg := range args {
+		opt := OptionDoc{}
+		expr := astutil.Unparen(arg)
+		call, isCall := expr.(*ast.CallExpr)
+		if isCall {
+			expr = call.Fun
+		}
+
+		opt.Name = optionName(pkg, expr)
+		if isCall {
+			for _, a := range call.Args {
+				opt.Args = append(opt.Args, argValue(pkg, a))
+			}
+		}
+		opts = append(opts, opt)
+	}
+	return opts
+}
+
+func optionName(pkg *packages.Package, expr ast.Expr) string {
+	var ident *ast.Ident
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	}
+	if ident == nil {
+		return types.ExprString(expr)
+	}
+
+	obj := pkg.TypesInfo.Uses[ident]
+	if obj == nil || obj.Pkg() == nil {
+		return types.ExprString(expr)
+	}
+	if obj.Pkg().Path() == errdefPkgPath {
+		return obj.Name()
+	}
+	if obj.Pkg() == pkg.Types {
+		return obj.Name()
+	}
+	return obj.Pkg().Name() + "." + obj.Name()
+}
+
+func argValue(pkg *packages.Package, expr ast.Expr) any {
+	tv, ok := pkg.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil {
+		return types.ExprString(expr)
+	}
+	switch tv.Value.Kind() {
+	case constant.String:
+		return constant.StringVal(tv.Value)
+	case constant.Bool:
+		return constant.BoolVal(tv.Value)
+	case constant.Int:
+		if v, ok := constant.Int64Val(tv.Value); ok {
+			return v
+		}
+	case constant.Float:
+		if v, ok := constant.Float64Val(tv.Value); ok {
+			return v
+		}
+	}
+	return tv.Value.ExactString()
+}
+
+func fieldType(pkg *packages.Package, call *ast.CallExpr) string {
+	var (
+		fun *ast.Ident
+		ok  bool
+	)
+	switch f := astutil.Unparen(call.Fun).(type) {
+	case *ast.IndexExpr:
+		fun, ok = selectorIdent(f.X)
+	case *ast.IndexListExpr:
+		fun, ok = selectorIdent(f.X)
+	default:
+		fun, ok = selectorIdent(f)
+	}
+	if !ok {
+		return ""
+	}
+	inst, ok := pkg.TypesInfo.Instances[fun]
+	if !ok || inst.TypeArgs.Len() == 0 {
+		return ""
+	}
+	return types.TypeString(inst.TypeArgs.At(0), func(p *types.Package) string {
+		if p == pkg.Types {
+			return ""
+		}
+		return p.Name()
+	})
+}
+
+func selectorIdent(expr ast.Expr) (*ast.Ident, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e, true
+	case *ast.SelectorExpr:
+		return e.Sel, true
+	}
+	return nil, false
+}
+
+func constantString(info *types.Info, expr ast.Expr) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+func position(fset *token.FileSet, pos token.Pos, dir string) string {
+	p := fset.Position(pos)
+	file := p.Filename
+	if rel, err := filepath.Rel(dir, file); err == nil && !strings.HasPrefix(rel, "..") {
+		file = filepath.ToSlash(rel)
+	}
+	return fmt.Sprintf("%s:%d", file, p.Line)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadCatalog(t *testing.T) {
+	c, err := LoadCatalog("testdata/catalog", "./...")
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	t.Run("definitions", func(t *testing.T) {
+		want := []DefinitionDoc{
+			{
+				Kind:     "billing.card_declined",
+				Name:     "ErrCardDeclined",
+				Package:  "example.com/catalog/billing",
+				Doc:      "ErrCardDeclined indicates that the payment card was declined.",
+				Position: "billing/billing.go:13",
+				Options: []OptionDoc{
+					{Name: "HTTPStatus", Args: []any{int64(402)}},
+					{Name: "errdefs.OwnerTeam", Args: []any{"payments"}},
+				},
+			},
+			{
+				Kind:     "not_found",
+				Name:     "ErrNotFound",
+				Package:  "example.com/catalog/errdefs",
+				Doc:      "ErrNotFound indicates that a requested resource was not found.",
+				Position: "errdefs/errdefs.go:7",
+				Options: []OptionDoc{
+					{Name: "HTTPStatus", Args: []any{int64(404)}},
+					{Name: "Public"},
+				},
+			},
+			{
+				Kind:     "unavailable",
+				Name:     "ErrUnavailable",
+				Package:  "example.com/catalog/errdefs",
+				Doc:      "ErrUnavailable indicates that a dependency is temporarily unavailable.",
+				Position: "errdefs/errdefs.go:10",
+				Options: []OptionDoc{
+					{Name: "HTTPStatus", Args: []any{int64(503)}},
+					{Name: "Retryable"},
+					{Name: "OwnerTeam", Args: []any{"platform"}},
+				},
+			},
+		}
+		if !reflect.DeepEqual(c.Definitions, want) {
+			t.Errorf("want %+v, got %+v", want, c.Definitions)
+		}
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		type field struct{ name, typ, constructor, extractor string }
+		want := []field{
+			{"card_token", "string", "CardToken", "CardTokenFrom"},
+			{"timeout", "time.Duration", "Timeout", "TimeoutFrom"},
+			{"amounts", "map[string]Amount", "Amounts", "AmountsFrom"},
+			{"user_id", "string", "UserID", "UserIDFrom"},
+			{"owner_team", "string", "OwnerTeam", ""},
+		}
+		var got []field
+		for _, f := range c.Fields {
+			got = append(got, field{f.Name, f.Type, f.Constructor, f.Extractor})
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("want %+v, got %+v", want, got)
+		}
+
+		if opts := c.Fields[0].Options; !reflect.DeepEqual(opts, []OptionDoc{{Name: "Sensitive"}}) {
+			t.Errorf("want Sensitive option, got %+v", opts)
+		}
+	})
+}
+
+func TestLoadCatalog_InvalidPackage(t *testing.T) {
+	if _, err := LoadCatalog("testdata/catalog", "./notfound"); err == nil {
+		t.Error("want error, got nil")
+	}
+}
@@ -0,0 +1,74 @@
+// Command errdef-doc generates a catalog of error kinds and fields defined with errdef.
+//
+// It statically analyzes the given packages, finds every errdef.Define and
+// errdef.DefineField call, and writes the kinds, field names, field types,
+// and literal option values (e.g., HTTPStatus(404), Public()) as Markdown or JSON.
+//
+// Usage:
+//
+//	errdef-doc [flags] [packages]
+//
+// Flags:
+//
+//	-format string   output format: markdown or json (default "markdown")
+//	-o string        output file (default: stdout)
+//	-title string    title of the Markdown document (default "Error Catalog")
+//
+// Packages default to "./...".
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "errdef-doc: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("errdef-doc", flag.ContinueOnError)
+	format := fs.String("format", "markdown", "output format: markdown or json")
+	output := fs.String("o", "", "output file (default: stdout)")
+	title := fs.String("title", "Error Catalog", "title of the Markdown document")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var render func(w io.Writer, catalog *Catalog) error
+	switch *format {
+	case "markdown", "md":
+		render = func(w io.Writer, catalog *Catalog) error { return WriteMarkdown(w, catalog, *title) }
+	case "json":
+		render = WriteJSON
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	catalog, err := LoadCatalog(".", patterns...)
+	if err != nil {
+		return err
+	}
+
+	// Render into a buffer first, so that the output file is not truncated on failure.
+	var buf bytes.Buffer
+	if err := render(&buf, catalog); err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(*output, buf.Bytes(), 0o644)
+}
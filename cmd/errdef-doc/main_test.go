@@ -0,0 +1,28 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRun_UnknownFormat(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.md")
+	if err := os.WriteFile(out, []byte("existing"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := run([]string{"-format", "bogus", "-o", out, "."}, io.Discard)
+	if err == nil || err.Error() != `unknown format "bogus"` {
+		t.Fatalf("want unknown format error, got %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "existing" {
+		t.Errorf("want output file to be left untouched, got %q", data)
+	}
+}
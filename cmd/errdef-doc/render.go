@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteJSON writes the catalog as indented JSON.
+func WriteJSON(w io.Writer, c *Catalog) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
+
+// WriteMarkdown writes the catalog as Markdown tables grouped by package.
+func WriteMarkdown(w io.Writer, c *Catalog, title string) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n", title)
+
+	if len(c.Definitions) > 0 {
+		b.WriteString("\n## Error Kinds\n")
+		pkg := ""
+		for _, d := range c.Definitions {
+			if d.Package != pkg {
+				pkg = d.Package
+				fmt.Fprintf(&b, "\n### `%s`\n\n", pkg)
+				b.WriteString("| Kind | Variable | Options | Description |\n")
+				b.WriteString("|:-----|:---------|:--------|:------------|\n")
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n",
+				d.Kind, code(d.Name), formatOptions(d.Options), cell(d.Doc))
+		}
+	}
+
+	if len(c.Fields) > 0 {
+		b.WriteString("\n## Fields\n")
+		pkg := ""
+		for _, f := range c.Fields {
+			if f.Package != pkg {
+				pkg = f.Package
+				fmt.Fprintf(&b, "\n### `%s`\n\n", pkg)
+				b.WriteString("| Name | Type | Constructor | Extractor | Options | Description |\n")
+				b.WriteString("|:-----|:-----|:------------|:----------|:--------|:------------|\n")
+			}
+			fmt.Fprintf(&b, "| `%s` | `%s` | %s | %s | %s | %s |\n",
+				f.Name, f.Type, code(f.Constructor), code(f.Extractor), formatOptions(f.Options), cell(f.Doc))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatOptions(opts []OptionDoc) string {
+	if len(opts) == 0 {
+		return "-"
+	}
+	s := make([]string, len(opts))
+	for i, o := range opts {
+		args := make([]string, len(o.Args))
+		for j, a := range o.Args {
+			if str, ok := a.(string); ok {
+				args[j] = fmt.Sprintf("%q", str)
+			} else {
+				args[j] = fmt.Sprint(a)
+			}
+		}
+		s[i] = code(fmt.Sprintf("%s(%s)", o.Name, strings.Join(args, ", ")))
+	}
+	return strings.Join(s, "<br>")
+}
+
+func code(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return "`" + strings.ReplaceAll(s, "|", `\|`) + "`"
+}
+
+func cell(s string) string {
+	if s == "" {
+		return "-"
+	}
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
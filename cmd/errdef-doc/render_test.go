@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+var testCatalog = &Catalog{
+	Definitions: []DefinitionDoc{
+		{
+			Kind:     "not_found",
+			Name:     "ErrNotFound",
+			Package:  "example.com/errdefs",
+			Doc:      "ErrNotFound indicates that a resource\nwas not found.",
+			Position: "errdefs.go:7",
+			Options: []OptionDoc{
+				{Name: "HTTPStatus", Args: []any{int64(404)}},
+				{Name: "Public"},
+			},
+		},
+		{
+			Kind:     "internal",
+			Package:  "example.com/errdefs",
+			Position: "errdefs.go:10",
+		},
+	},
+	Fields: []FieldDoc{
+		{
+			Name:        "user_id",
+			Type:        "string",
+			Constructor: "UserID",
+			Extractor:   "UserIDFrom",
+			Package:     "example.com/errdefs",
+			Position:    "errdefs.go:14",
+			Options:     []OptionDoc{{Name: "PublicField"}},
+		},
+	},
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, testCatalog, "Errors"); err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	want := "# Errors\n" +
+		"\n## Error Kinds\n" +
+		"\n### `example.com/errdefs`\n\n" +
+		"| Kind | Variable | Options | Description |\n" +
+		"|:-----|:---------|:--------|:------------|\n" +
+		"| `not_found` | `ErrNotFound` | `HTTPStatus(404)`<br>`Public()` | ErrNotFound indicates that a resource was not found. |\n" +
+		"| `internal` | - | - | - |\n" +
+		"\n## Fields\n" +
+		"\n### `example.com/errdefs`\n\n" +
+		"| Name | Type | Constructor | Extractor | Options | Description |\n" +
+		"|:-----|:-----|:------------|:----------|:--------|:------------|\n" +
+		"| `user_id` | `string` | `UserID` | `UserIDFrom` | `PublicField()` | - |\n"
+	if got := buf.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, testCatalog); err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	var got Catalog
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+	if len(got.Definitions) != 2 || len(got.Fields) != 1 {
+		t.Fatalf("unexpected catalog: %+v", got)
+	}
+	if got.Definitions[0].Options[0].Args[0] != float64(404) {
+		t.Errorf("want 404, got %v", got.Definitions[0].Options[0].Args[0])
+	}
+}
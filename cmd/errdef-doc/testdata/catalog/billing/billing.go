@@ -0,0 +1,30 @@
+package billing
+
+import (
+	"time"
+
+	"example.com/catalog/errdefs"
+	"github.com/shiwano/errdef"
+)
+
+const kindPrefix = "billing."
+
+// ErrCardDeclined indicates that the payment card was declined.
+var ErrCardDeclined = errdef.Define(kindPrefix+"card_declined", errdef.HTTPStatus(402), errdefs.OwnerTeam("payments"))
+
+var (
+	// CardToken stores the payment card token.
+	CardToken, CardTokenFrom = errdef.DefineField[string]("card_token", errdef.Sensitive())
+
+	// Timeout stores the timeout of the payment gateway.
+	Timeout, TimeoutFrom = errdef.DefineField[time.Duration]("timeout")
+
+	// Amounts stores the amounts of the payment.
+	Amounts, AmountsFrom = errdef.DefineField[map[string]Amount]("amounts")
+)
+
+// Amount is a monetary amount.
+type Amount struct {
+	Value    int64
+	Currency string
+}
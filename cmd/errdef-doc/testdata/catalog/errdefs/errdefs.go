@@ -0,0 +1,19 @@
+package errdefs
+
+import "github.com/shiwano/errdef"
+
+var (
+	// ErrNotFound indicates that a requested resource was not found.
+	ErrNotFound = errdef.Define("not_found", errdef.HTTPStatus(404), errdef.Public())
+
+	// ErrUnavailable indicates that a dependency is temporarily unavailable.
+	ErrUnavailable = errdef.Define("unavailable", errdef.HTTPStatus(503), errdef.Retryable(), OwnerTeam("platform"))
+)
+
+var (
+	// UserID stores user identifiers.
+	UserID, UserIDFrom = errdef.DefineField[string]("user_id", errdef.PublicField())
+
+	// OwnerTeam labels the team that owns the error.
+	OwnerTeam, _ = errdef.DefineField[string]("owner_team")
+)
@@ -0,0 +1,7 @@
+module example.com/catalog
+
+go 1.25.0
+
+require github.com/shiwano/errdef v0.0.0
+
+replace github.com/shiwano/errdef => ../../../..
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	errdefPkgPath      = "github.com/shiwano/errdef"
+	resolverPkgPath    = "github.com/shiwano/errdef/resolver"
+	unmarshalerPkgPath = "github.com/shiwano/errdef/unmarshaler"
+)
+
+// Generate writes the Go source generated from the schema.
+// The source is used in the generated header comment.
+func Generate(w io.Writer, s *Schema, pkg, source string) error {
+	if pkg == "" {
+		pkg = s.Package
+	}
+	if !token.IsIdentifier(pkg) {
+		return fmt.Errorf("invalid package name %q; set package in the schema or the -package flag", pkg)
+	}
+
+	imports, err := s.importPaths()
+	if err != nil {
+		return err
+	}
+	imports = append(imports, errdefPkgPath, resolverPkgPath)
+	if len(s.Fields) > 0 {
+		imports = append(imports, unmarshalerPkgPath)
+	}
+	for _, k := range s.Kinds {
+		if k.Message != "" {
+			imports = append(imports, "context")
+		}
+		if k.LogLevel != "" {
+			imports = append(imports, "log/slog")
+		}
+	}
+
+	var b bytes.Buffer
+	if source != "" {
+		fmt.Fprintf(&b, "// Code generated by errdefgen from %s. DO NOT EDIT.\n\n", source)
+	} else {
+		b.WriteString("// Code generated by errdefgen. DO NOT EDIT.\n\n")
+	}
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	writeImports(&b, imports)
+
+	if len(s.Fields) > 0 {
+		b.WriteString("var (\n")
+		for i, f := range s.Fields {
+			if i > 0 && (f.Description != "" || s.Fields[i-1].Description != "") {
+				b.WriteString("\n")
+			}
+			writeComment(&b, f.Description)
+			args := []string{strconv.Quote(f.Name)}
+			if f.Sensitive {
+				args = append(args, "errdef.Sensitive()")
+			}
+			if f.Public {
+				args = append(args, "errdef.PublicField()")
+			}
+			fmt.Fprintf(&b, "%s, %sFrom = errdef.DefineField[%s](%s)\n", f.GoName, f.GoName, f.Type, strings.Join(args, ", "))
+		}
+		b.WriteString(")\n\n")
+	}
+
+	if len(s.Kinds) > 0 {
+		b.WriteString("var (\n")
+		for i, k := range s.Kinds {
+			if i > 0 && (k.Description != "" || s.Kinds[i-1].Description != "") {
+				b.WriteString("\n")
+			}
+			writeComment(&b, k.Description)
+			fmt.Fprintf(&b, "%s = errdef.Define(%s)\n", k.GoName, strings.Join(kindArgs(k), ", "))
+		}
+		b.WriteString(")\n\n")
+	}
+
+	fmt.Fprintf(&b, "// %s resolves the kinds defined in this file.\n", s.Resolver)
+	fmt.Fprintf(&b, "var %s = resolver.New(\n", s.Resolver)
+	for _, k := range s.Kinds {
+		fmt.Fprintf(&b, "%s,\n", k.GoName)
+	}
+	b.WriteString(")\n")
+
+	if len(s.Fields) > 0 {
+		b.WriteString("\n// UnmarshalerOptions returns the unmarshaler options that register the fields defined in this file.\n")
+		b.WriteString("func UnmarshalerOptions() []unmarshaler.Option {\n")
+		b.WriteString("return []unmarshaler.Option{\nunmarshaler.WithCustomFields(\n")
+		for _, f := range s.Fields {
+			fmt.Fprintf(&b, "%s.Key(),\n", f.GoName)
+		}
+		b.WriteString("),\n}\n}\n")
+	}
+
+	reserved := make(map[string]bool)
+	for _, p := range imports {
+		reserved[importName(p)] = true
+	}
+	for _, k := range s.Kinds {
+		if k.Message != "" {
+			b.WriteString("\n")
+			writeConstructor(&b, s, &k, reserved)
+		}
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated code: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+func kindArgs(k KindSchema) []string {
+	args := []string{strconv.Quote(k.Kind)}
+	if k.HTTPStatus != 0 {
+		args = append(args, fmt.Sprintf("errdef.HTTPStatus(%d)", k.HTTPStatus))
+	}
+	if k.LogLevel != "" {
+		args = append(args, fmt.Sprintf("errdef.LogLevel(%s)", logLevels[k.LogLevel]))
+	}
+	if k.Public {
+		args = append(args, "errdef.Public()")
+	}
+	if k.Retryable {
+		args = append(args, "errdef.Retryable()")
+	}
+	if k.HelpURL != "" {
+		args = append(args, fmt.Sprintf("errdef.HelpURL(%s)", strconv.Quote(k.HelpURL)))
+	}
+	return args
+}
+
+func writeConstructor(b *bytes.Buffer, s *Schema, k *KindSchema, reserved map[string]bool) {
+	tmpl, _ := parseMessage(k.Message) // validated by LoadSchema
+
+	var (
+		params []string
+		opts   []string
+		args   []string
+	)
+	paramNames := make(map[string]string)
+	for _, name := range tmpl.fields {
+		if _, ok := paramNames[name]; !ok {
+			f := s.field(name)
+			p := lowerCamelCase(f.GoName)
+			if p == "" || token.IsKeyword(p) || p == "ctx" || reserved[p] {
+				p += "Value"
+			}
+			paramNames[name] = p
+			params = append(params, fmt.Sprintf("%s %s", p, f.Type))
+			opts = append(opts, fmt.Sprintf("%s(%s)", f.GoName, p))
+		}
+		args = append(args, paramNames[name])
+	}
+
+	name := constructorName(k)
+	fmt.Fprintf(b, "// %s returns a new %s error with the message %s.\n", name, k.GoName, strconv.Quote(k.Message))
+	fmt.Fprintf(b, "func %s(%s) error {\n", name, strings.Join(append([]string{"ctx context.Context"}, params...), ", "))
+	factory := fmt.Sprintf("%s.With(%s)", k.GoName, strings.Join(append([]string{"ctx"}, opts...), ", "))
+	if len(args) == 0 {
+		fmt.Fprintf(b, "return %s.New(%s)\n}\n", factory, strconv.Quote(strings.ReplaceAll(tmpl.format, "%%", "%")))
+		return
+	}
+	fmt.Fprintf(b, "return %s.Errorf(%s, %s)\n}\n", factory, strconv.Quote(tmpl.format), strings.Join(args, ", "))
+}
+
+func (s *Schema) field(name string) *FieldSchema {
+	for i := range s.Fields {
+		if s.Fields[i].Name == name {
+			return &s.Fields[i]
+		}
+	}
+	return nil
+}
+
+// writeImports writes the import declaration, with the standard packages
+// grouped before the others.
+func writeImports(b *bytes.Buffer, paths []string) {
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	var std, others []string
+	for _, p := range paths {
+		if strings.Contains(strings.SplitN(p, "/", 2)[0], ".") {
+			others = append(others, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+
+	b.WriteString("import (\n")
+	for _, p := range std {
+		fmt.Fprintf(b, "%s\n", strconv.Quote(p))
+	}
+	if len(std) > 0 && len(others) > 0 {
+		b.WriteString("\n")
+	}
+	for _, p := range others {
+		fmt.Fprintf(b, "%s\n", strconv.Quote(p))
+	}
+	b.WriteString(")\n\n")
+}
+
+func writeComment(b *bytes.Buffer, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimRight(line, " \t"); line == "" {
+			b.WriteString("//\n")
+		} else {
+			fmt.Fprintf(b, "// %s\n", line)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("golden", func(t *testing.T) {
+		f, err := os.Open("testdata/apperr/errors.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = f.Close() }()
+
+		s, err := LoadSchema(f)
+		if err != nil {
+			t.Fatalf("want no error, got %v", err)
+		}
+		var buf bytes.Buffer
+		if err := Generate(&buf, s, "", "errors.yaml"); err != nil {
+			t.Fatalf("want no error, got %v", err)
+		}
+
+		// testdata/apperr is a module that compiles the generated code.
+		want, err := os.ReadFile("testdata/apperr/errors_gen.go")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != string(want) {
+			t.Errorf("generated code differs from testdata/apperr/errors_gen.go; regenerate it with:\n"+
+				"go run . -o testdata/apperr/errors_gen.go testdata/apperr/errors.yaml\n\ngot:\n%s", got)
+		}
+	})
+
+	t.Run("package flag", func(t *testing.T) {
+		s, err := LoadSchema(strings.NewReader("kinds:\n  - kind: a\n"))
+		if err != nil {
+			t.Fatalf("want no error, got %v", err)
+		}
+		var buf bytes.Buffer
+		if err := Generate(&buf, s, "", ""); err == nil || !strings.Contains(err.Error(), "invalid package name") {
+			t.Errorf("want invalid package name error, got %v", err)
+		}
+
+		buf.Reset()
+		if err := Generate(&buf, s, "errs", ""); err != nil {
+			t.Fatalf("want no error, got %v", err)
+		}
+		got := buf.String()
+		for _, want := range []string{
+			"// Code generated by errdefgen. DO NOT EDIT.",
+			"package errs",
+			`ErrA = errdef.Define("a")`,
+			"var Resolver = resolver.New(\n\tErrA,\n)",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("want output containing %q, got:\n%s", want, got)
+			}
+		}
+		if strings.Contains(got, "unmarshaler") || strings.Contains(got, "context") {
+			t.Errorf("want no unused imports, got:\n%s", got)
+		}
+	})
+
+	t.Run("unknown package", func(t *testing.T) {
+		s, err := LoadSchema(strings.NewReader("package: errs\nfields:\n  - name: id\n    type: uuid.UUID\n"))
+		if err != nil {
+			t.Fatalf("want no error, got %v", err)
+		}
+		var buf bytes.Buffer
+		if err := Generate(&buf, s, "", ""); err == nil || !strings.Contains(err.Error(), `unknown package "uuid"`) {
+			t.Errorf("want unknown package error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,5 @@
+module github.com/shiwano/errdef/cmd/errdefgen
+
+go 1.25.0
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,89 @@
+// Command errdefgen generates errdef definitions from an error schema.
+//
+// The schema is a YAML or JSON file listing fields and kinds, which can be
+// shared with services written in other languages to keep kinds consistent:
+//
+//	package: apperr
+//	fields:
+//	  - name: user_id
+//	    type: string
+//	    public: true
+//	kinds:
+//	  - kind: user_not_found
+//	    description: ErrUserNotFound indicates that the user does not exist.
+//	    http_status: 404
+//	    public: true
+//	    message: "user {user_id} not found"
+//
+// From the schema, errdefgen generates errdef.DefineField pairs (UserID and
+// UserIDFrom), errdef.Define vars (ErrUserNotFound), a Resolver created by
+// resolver.New with all the definitions, an UnmarshalerOptions function that
+// registers the fields with unmarshaler.WithCustomFields, and a constructor for
+// each kind with a message template:
+//
+//	func NewUserNotFound(ctx context.Context, userID string) error
+//
+// Usage:
+//
+//	errdefgen [flags] schema.yaml
+//
+// Flags:
+//
+//	-o string         output file (default: stdout)
+//	-package string   package name (default: the package in the schema)
+//
+// It is typically used with go generate:
+//
+//	//go:generate go run github.com/shiwano/errdef/cmd/errdefgen -o errors_gen.go errors.yaml
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "errdefgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("errdefgen", flag.ContinueOnError)
+	output := fs.String("o", "", "output file (default: stdout)")
+	pkg := fs.String("package", "", "package name (default: the package in the schema)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: errdefgen [flags] schema.yaml")
+	}
+
+	path := fs.Arg(0)
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	schema, err := LoadSchema(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	var buf bytes.Buffer
+	if err := Generate(&buf, schema, *pkg, filepath.Base(path)); err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(*output, buf.Bytes(), 0o644)
+}
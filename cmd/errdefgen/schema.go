@@ -0,0 +1,384 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"path"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Schema is an error schema shared across services.
+	// It is written in YAML or JSON.
+	Schema struct {
+		// Package is the name of the generated Go package.
+		Package string `yaml:"package" json:"package"`
+		// Imports lists the import paths of packages referred to by field types,
+		// other than the standard packages detected automatically.
+		Imports []string `yaml:"imports" json:"imports"`
+		// Resolver is the name of the generated resolver var (default "Resolver").
+		Resolver string `yaml:"resolver" json:"resolver"`
+		// Fields lists the field definitions.
+		Fields []FieldSchema `yaml:"fields" json:"fields"`
+		// Kinds lists the error definitions.
+		Kinds []KindSchema `yaml:"kinds" json:"kinds"`
+	}
+
+	// FieldSchema describes a field defined with errdef.DefineField.
+	FieldSchema struct {
+		// Name is the field name (e.g., "user_id").
+		Name string `yaml:"name" json:"name"`
+		// Type is the Go type of the field value (e.g., "string", "time.Duration").
+		Type string `yaml:"type" json:"type"`
+		// Description is written as the doc comment.
+		Description string `yaml:"description" json:"description"`
+		// Sensitive marks the field with errdef.Sensitive.
+		Sensitive bool `yaml:"sensitive" json:"sensitive"`
+		// Public marks the field with errdef.PublicField.
+		Public bool `yaml:"public" json:"public"`
+		// GoName overrides the name of the constructor (default: the name in CamelCase).
+		// The extractor is named GoName + "From".
+		GoName string `yaml:"go_name" json:"go_name"`
+	}
+
+	// KindSchema describes an error defined with errdef.Define.
+	KindSchema struct {
+		// Kind is the error kind (e.g., "not_found").
+		Kind string `yaml:"kind" json:"kind"`
+		// Description is written as the doc comment.
+		Description string `yaml:"description" json:"description"`
+		// HTTPStatus sets errdef.HTTPStatus if non-zero.
+		HTTPStatus int `yaml:"http_status" json:"http_status"`
+		// Retryable sets errdef.Retryable.
+		Retryable bool `yaml:"retryable" json:"retryable"`
+		// Public sets errdef.Public.
+		Public bool `yaml:"public" json:"public"`
+		// LogLevel sets errdef.LogLevel: "debug", "info", "warn", or "error".
+		LogLevel string `yaml:"log_level" json:"log_level"`
+		// HelpURL sets errdef.HelpURL if non-empty.
+		HelpURL string `yaml:"help_url" json:"help_url"`
+		// Message is the message template of the generated constructor.
+		// Placeholders such as {user_id} refer to fields, which become parameters.
+		// Sensitive fields cannot be referred to, since their values would be
+		// formatted into the message without redaction.
+		// Use {{ and }} for literal braces.
+		Message string `yaml:"message" json:"message"`
+		// GoName overrides the name of the definition var (default: "Err" + the kind in CamelCase).
+		GoName string `yaml:"go_name" json:"go_name"`
+	}
+)
+
+var (
+	logLevels = map[string]string{
+		"debug": "slog.LevelDebug",
+		"info":  "slog.LevelInfo",
+		"warn":  "slog.LevelWarn",
+		"error": "slog.LevelError",
+	}
+
+	// stdImports maps the package names referred to by field types to
+	// the standard packages imported automatically.
+	stdImports = map[string]string{
+		"time":   "time",
+		"slog":   "log/slog",
+		"url":    "net/url",
+		"netip":  "net/netip",
+		"json":   "encoding/json",
+		"big":    "math/big",
+		"fs":     "io/fs",
+		"http":   "net/http",
+		"net":    "net",
+		"errors": "errors",
+	}
+)
+
+// LoadSchema reads a schema in YAML or JSON and validates it.
+func LoadSchema(r io.Reader) (*Schema, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var s Schema
+	if err := dec.Decode(&s); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("empty schema")
+		}
+		return nil, err
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *Schema) validate() error {
+	if s.Resolver == "" {
+		s.Resolver = "Resolver"
+	}
+	if !token.IsIdentifier(s.Resolver) || !token.IsExported(s.Resolver) {
+		return fmt.Errorf("resolver %q is not an exported identifier", s.Resolver)
+	}
+	names := map[string]string{s.Resolver: "resolver"}
+	declare := func(name, what string) error {
+		if prev, ok := names[name]; ok {
+			return fmt.Errorf("%s: Go name %s is already used by %s", what, name, prev)
+		}
+		names[name] = what
+		return nil
+	}
+
+	fields := make(map[string]bool)
+	sensitive := make(map[string]bool)
+	for i := range s.Fields {
+		f := &s.Fields[i]
+		what := fmt.Sprintf("field %q", f.Name)
+		if f.Name == "" {
+			return fmt.Errorf("fields[%d]: name is required", i)
+		}
+		if fields[f.Name] {
+			return fmt.Errorf("%s is defined more than once", what)
+		}
+		fields[f.Name] = true
+		if f.Sensitive {
+			sensitive[f.Name] = true
+		}
+
+		if f.Type == "" {
+			return fmt.Errorf("%s: type is required", what)
+		}
+		if _, err := parser.ParseExpr(f.Type); err != nil {
+			return fmt.Errorf("%s: invalid type %q", what, f.Type)
+		}
+		if f.GoName == "" {
+			f.GoName = camelCase(f.Name)
+		}
+		if !token.IsIdentifier(f.GoName) || !token.IsExported(f.GoName) {
+			return fmt.Errorf("%s: %q is not an exported identifier; set go_name", what, f.GoName)
+		}
+		if err := declare(f.GoName, what); err != nil {
+			return err
+		}
+		if err := declare(f.GoName+"From", what); err != nil {
+			return err
+		}
+	}
+
+	kinds := make(map[string]bool)
+	for i := range s.Kinds {
+		k := &s.Kinds[i]
+		what := fmt.Sprintf("kind %q", k.Kind)
+		if k.Kind == "" {
+			return fmt.Errorf("kinds[%d]: kind is required", i)
+		}
+		if kinds[k.Kind] {
+			return fmt.Errorf("%s is defined more than once", what)
+		}
+		kinds[k.Kind] = true
+
+		if k.HTTPStatus != 0 && (k.HTTPStatus < 100 || k.HTTPStatus > 599) {
+			return fmt.Errorf("%s: invalid http_status %d", what, k.HTTPStatus)
+		}
+		if _, ok := logLevels[k.LogLevel]; k.LogLevel != "" && !ok {
+			return fmt.Errorf("%s: invalid log_level %q; want debug, info, warn, or error", what, k.LogLevel)
+		}
+		if k.GoName == "" {
+			k.GoName = "Err" + camelCase(k.Kind)
+		}
+		if !token.IsIdentifier(k.GoName) || !token.IsExported(k.GoName) {
+			return fmt.Errorf("%s: %q is not an exported identifier; set go_name", what, k.GoName)
+		}
+		if err := declare(k.GoName, what); err != nil {
+			return err
+		}
+		if k.Message != "" {
+			tmpl, err := parseMessage(k.Message)
+			if err != nil {
+				return fmt.Errorf("%s: %w", what, err)
+			}
+			for _, p := range tmpl.fields {
+				if !fields[p] {
+					return fmt.Errorf("%s: message refers to undefined field %q", what, p)
+				}
+				if sensitive[p] {
+					return fmt.Errorf("%s: message refers to sensitive field %q, whose value would not be redacted", what, p)
+				}
+			}
+			if err := declare(constructorName(k), what); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, imp := range s.Imports {
+		if imp == "" || strings.ContainsAny(imp, " \t\"") {
+			return fmt.Errorf("invalid import path %q", imp)
+		}
+	}
+	return nil
+}
+
+// importPaths returns the import paths required by the field types.
+func (s *Schema) importPaths() ([]string, error) {
+	declared := make(map[string]string)
+	for _, imp := range s.Imports {
+		declared[importName(imp)] = imp
+	}
+
+	var paths []string
+	for _, f := range s.Fields {
+		expr, err := parser.ParseExpr(f.Type)
+		if err != nil {
+			return nil, err
+		}
+		var missing string
+		ast.Inspect(expr, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			x, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if p, ok := declared[x.Name]; ok {
+				paths = append(paths, p)
+			} else if p, ok := stdImports[x.Name]; ok {
+				paths = append(paths, p)
+			} else if missing == "" {
+				missing = x.Name
+			}
+			return false
+		})
+		if missing != "" {
+			return nil, fmt.Errorf("field %q: unknown package %q in type %q; add its path to imports", f.Name, missing, f.Type)
+		}
+	}
+	return paths, nil
+}
+
+type messageTemplate struct {
+	format string
+	fields []string
+}
+
+// parseMessage converts a message template into a fmt format string and
+// the fields referred to by its placeholders, in order.
+func parseMessage(msg string) (messageTemplate, error) {
+	var (
+		b   strings.Builder
+		tpl messageTemplate
+	)
+	for i := 0; i < len(msg); i++ {
+		switch c := msg[i]; c {
+		case '{':
+			if strings.HasPrefix(msg[i:], "{{") {
+				b.WriteByte('{')
+				i++
+				continue
+			}
+			end := strings.IndexByte(msg[i:], '}')
+			if end < 0 {
+				return tpl, fmt.Errorf("unclosed placeholder in message %q", msg)
+			}
+			name := strings.TrimSpace(msg[i+1 : i+end])
+			if name == "" {
+				return tpl, fmt.Errorf("empty placeholder in message %q", msg)
+			}
+			tpl.fields = append(tpl.fields, name)
+			b.WriteString("%v")
+			i += end
+		case '}':
+			if !strings.HasPrefix(msg[i:], "}}") {
+				return tpl, fmt.Errorf("unmatched '}' in message %q; use '}}' for a literal brace", msg)
+			}
+			b.WriteByte('}')
+			i++
+		case '%':
+			b.WriteString("%%")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	tpl.format = b.String()
+	return tpl, nil
+}
+
+func constructorName(k *KindSchema) string {
+	return "New" + strings.TrimPrefix(k.GoName, "Err")
+}
+
+// initialisms are the words written in all capitals in Go names.
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true,
+	"EOF": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IP": true, "JSON": true, "QPS": true, "RAM": true, "RPC": true, "SLA": true,
+	"SMTP": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true, "TTL": true,
+	"UDP": true, "UI": true, "UID": true, "UUID": true, "URI": true, "URL": true,
+	"UTF8": true, "VM": true, "XML": true, "XSRF": true, "XSS": true,
+}
+
+// words splits a kind or field name such as "auth.invalid_token" into words.
+func words(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// camelCase converts a kind or field name into an exported Go name,
+// e.g., "user_id" into "UserID".
+func camelCase(s string) string {
+	var b strings.Builder
+	for _, w := range words(s) {
+		if up := strings.ToUpper(w); initialisms[up] {
+			b.WriteString(up)
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// lowerCamelCase converts an exported Go name into a parameter name,
+// e.g., "UserID" into "userID" and "URLPath" into "urlPath".
+func lowerCamelCase(s string) string {
+	runes := []rune(s)
+	n := 0
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+	if n > 1 && n < len(runes) && unicode.IsLower(runes[n]) {
+		n-- // the last upper case letter starts the next word
+	}
+	for i := range n {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// importName returns the package name assumed for an import path,
+// ignoring a major version suffix such as "/v2".
+func importName(p string) string {
+	base := path.Base(p)
+	if len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" {
+		base = path.Base(path.Dir(p))
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexAny(base, ".-"); i >= 0 {
+		base = base[:i]
+	}
+	if !token.IsIdentifier(base) {
+		return ""
+	}
+	return base
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadSchema(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		s, err := LoadSchema(strings.NewReader(`
+package: apperr
+fields:
+  - name: user_id
+    type: string
+kinds:
+  - kind: auth.invalid_token
+    message: "invalid token for {user_id}"
+`))
+		if err != nil {
+			t.Fatalf("want no error, got %v", err)
+		}
+		if s.Resolver != "Resolver" {
+			t.Errorf("want resolver %q, got %q", "Resolver", s.Resolver)
+		}
+		if s.Fields[0].GoName != "UserID" {
+			t.Errorf("want field Go name %q, got %q", "UserID", s.Fields[0].GoName)
+		}
+		if s.Kinds[0].GoName != "ErrAuthInvalidToken" {
+			t.Errorf("want kind Go name %q, got %q", "ErrAuthInvalidToken", s.Kinds[0].GoName)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		s, err := LoadSchema(strings.NewReader(`{
+			"package": "apperr",
+			"kinds": [{"kind": "not_found", "http_status": 404, "public": true}]
+		}`))
+		if err != nil {
+			t.Fatalf("want no error, got %v", err)
+		}
+		if k := s.Kinds[0]; k.Kind != "not_found" || k.HTTPStatus != 404 || !k.Public {
+			t.Errorf("unexpected kind: %+v", k)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{"empty", ``, "empty schema"},
+		{"unknown key", "kinds:\n  - kind: a\n    status: 404\n", "field status not found"},
+		{"missing kind", "kinds:\n  - http_status: 404\n", "kinds[0]: kind is required"},
+		{"duplicate kind", "kinds:\n  - kind: a\n  - kind: a\n", `kind "a" is defined more than once`},
+		{"missing type", "fields:\n  - name: a\n", `field "a": type is required`},
+		{"invalid type", "fields:\n  - name: a\n    type: '[]'\n", `field "a": invalid type`},
+		{"invalid status", "kinds:\n  - kind: a\n    http_status: 42\n", "invalid http_status 42"},
+		{"invalid log level", "kinds:\n  - kind: a\n    log_level: fatal\n", `invalid log_level "fatal"`},
+		{"undefined field", "kinds:\n  - kind: a\n    message: '{user_id}'\n", `message refers to undefined field "user_id"`},
+		{"sensitive field", "fields:\n  - name: email\n    type: string\n    sensitive: true\nkinds:\n  - kind: a\n    message: 'bad {email}'\n", `message refers to sensitive field "email"`},
+		{"unclosed placeholder", "kinds:\n  - kind: a\n    message: '{user_id'\n", "unclosed placeholder"},
+		{"unmatched brace", "kinds:\n  - kind: a\n    message: 'a } b'\n", "unmatched '}'"},
+		{"name conflict", "kinds:\n  - kind: a.b\n  - kind: a_b\n", "Go name ErrAB is already used"},
+		{"unexported name", "kinds:\n  - kind: a\n    go_name: errA\n", "not an exported identifier"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadSchema(strings.NewReader(tt.schema))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("want error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestParseMessage(t *testing.T) {
+	tmpl, err := parseMessage("{a} is 100% of {b} ({{c}})")
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+	if want := "%v is 100%% of %v ({c})"; tmpl.format != want {
+		t.Errorf("want format %q, got %q", want, tmpl.format)
+	}
+	if len(tmpl.fields) != 2 || tmpl.fields[0] != "a" || tmpl.fields[1] != "b" {
+		t.Errorf("want fields [a b], got %v", tmpl.fields)
+	}
+}
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		in, camel, lowerCamel string
+	}{
+		{"user_id", "UserID", "userID"},
+		{"http.status-code", "HTTPStatusCode", "httpStatusCode"},
+		{"url_path", "URLPath", "urlPath"},
+		{"id", "ID", "id"},
+		{"retry_after", "RetryAfter", "retryAfter"},
+	}
+	for _, tt := range tests {
+		if got := camelCase(tt.in); got != tt.camel {
+			t.Errorf("camelCase(%q): want %q, got %q", tt.in, tt.camel, got)
+		}
+		if got := lowerCamelCase(tt.camel); got != tt.lowerCamel {
+			t.Errorf("lowerCamelCase(%q): want %q, got %q", tt.camel, tt.lowerCamel, got)
+		}
+	}
+
+	for in, want := range map[string]string{
+		"github.com/google/uuid":      "uuid",
+		"gopkg.in/yaml.v3":            "yaml",
+		"github.com/jackc/pgx/v5":     "pgx",
+		"github.com/go-sql-driver/sq": "sq",
+	} {
+		if got := importName(in); got != want {
+			t.Errorf("importName(%q): want %q, got %q", in, want, got)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Code generated by errdefgen from errors.yaml. DO NOT EDIT.
+
+package apperr
+
+import (
+	"context"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/resolver"
+	"github.com/shiwano/errdef/unmarshaler"
+)
+
+var (
+	// UserID is the ID of the user related to the error.
+	UserID, UserIDFrom = errdef.DefineField[string]("user_id", errdef.PublicField())
+
+	Email, EmailFrom               = errdef.DefineField[string]("email", errdef.Sensitive())
+	ClientIP, ClientIPFrom         = errdef.DefineField[netip.Addr]("client_ip")
+	Timeout, TimeoutFrom           = errdef.DefineField[time.Duration]("timeout")
+	ResourceType, ResourceTypeFrom = errdef.DefineField[string]("type")
+)
+
+var (
+	// ErrUserNotFound indicates that the user does not exist.
+	ErrUserNotFound = errdef.Define("user_not_found", errdef.HTTPStatus(404), errdef.Public())
+
+	// ErrAuthInvalidToken indicates that the access token is invalid.
+	//
+	// The client should obtain a new token.
+	ErrAuthInvalidToken = errdef.Define("auth.invalid_token", errdef.HTTPStatus(401), errdef.LogLevel(slog.LevelInfo), errdef.Public(), errdef.HelpURL("https://example.com/docs/errors#invalid-token"))
+
+	ErrUpstreamTimeout = errdef.Define("upstream_timeout", errdef.HTTPStatus(504), errdef.LogLevel(slog.LevelWarn), errdef.Retryable())
+	ErrInternalServer  = errdef.Define("internal", errdef.HTTPStatus(500), errdef.LogLevel(slog.LevelError))
+	ErrConflict        = errdef.Define("conflict", errdef.HTTPStatus(409))
+)
+
+// Resolver resolves the kinds defined in this file.
+var Resolver = resolver.New(
+	ErrUserNotFound,
+	ErrAuthInvalidToken,
+	ErrUpstreamTimeout,
+	ErrInternalServer,
+	ErrConflict,
+)
+
+// UnmarshalerOptions returns the unmarshaler options that register the fields defined in this file.
+func UnmarshalerOptions() []unmarshaler.Option {
+	return []unmarshaler.Option{
+		unmarshaler.WithCustomFields(
+			UserID.Key(),
+			Email.Key(),
+			ClientIP.Key(),
+			Timeout.Key(),
+			ResourceType.Key(),
+		),
+	}
+}
+
+// NewUserNotFound returns a new ErrUserNotFound error with the message "user {user_id} not found".
+func NewUserNotFound(ctx context.Context, userID string) error {
+	return ErrUserNotFound.With(ctx, UserID(userID)).Errorf("user %v not found", userID)
+}
+
+// NewAuthInvalidToken returns a new ErrAuthInvalidToken error with the message "invalid token presented from {client_ip}".
+func NewAuthInvalidToken(ctx context.Context, clientIP netip.Addr) error {
+	return ErrAuthInvalidToken.With(ctx, ClientIP(clientIP)).Errorf("invalid token presented from %v", clientIP)
+}
+
+// NewUpstreamTimeout returns a new ErrUpstreamTimeout error with the message "{type} request timed out after {timeout} (100% of budget, {{retry}})".
+func NewUpstreamTimeout(ctx context.Context, resourceType string, timeout time.Duration) error {
+	return ErrUpstreamTimeout.With(ctx, ResourceType(resourceType), Timeout(timeout)).Errorf("%v request timed out after %v (100%% of budget, {retry})", resourceType, timeout)
+}
+
+// NewInternalServer returns a new ErrInternalServer error with the message "internal server error".
+func NewInternalServer(ctx context.Context) error {
+	return ErrInternalServer.With(ctx).New("internal server error")
+}
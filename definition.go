@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 	"strconv"
 	"strings"
 )
@@ -68,6 +69,10 @@ type (
 		// If a panic occurs, it wraps the panic as an error using this definition and returns it.
 		// If no panic occurs, it returns the function's return value as is.
 		// The resulting error implements PanicError interface to preserve the original panic value.
+		//
+		// Panics with http.ErrAbortHandler, or values matched by the RepanicWhen option,
+		// are re-raised instead. Recovered runtime.Error panics are marked with Bug.
+		// A call to runtime.Goexit within fn is not recovered.
 		Recover(fn func() error) error
 	}
 
@@ -105,6 +110,8 @@ type (
 		stackDepth       int
 		stackSourceLines int
 		stackSourceDepth int
+		repanicWhen      func(panicValue any) bool
+		messageScrubber  Scrubber
 		formatter        func(err Error, s fmt.State, verb rune)
 		jsonMarshaler    func(err Error) ([]byte, error)
 		logValuer        func(err Error) slog.Value
@@ -188,8 +195,16 @@ func (d *definition) Recover(fn func() error) error {
 	func() {
 		defer func() {
 			if panicValue := recover(); panicValue != nil {
+				if d.shouldRepanic(panicValue) {
+					panic(panicValue)
+				}
 				cause := newPanicError(panicValue)
-				err = newError(d, cause, fmt.Sprintf("panic: %s", cause.Error()), false, callersSkip+2)
+				def := d
+				if cause.Class() == PanicClassRuntimeError {
+					def = d.clone()
+					def.applyOptions([]Option{Bug()})
+				}
+				err = newError(def, cause, fmt.Sprintf("panic: %s", cause.Error()), false, callersSkip+2)
 			}
 		}()
 		err = fn()
@@ -197,6 +212,13 @@ func (d *definition) Recover(fn func() error) error {
 	return err
 }
 
+func (d *definition) shouldRepanic(panicValue any) bool {
+	if panicValue == http.ErrAbortHandler {
+		return true
+	}
+	return d.repanicWhen != nil && d.repanicWhen(panicValue)
+}
+
 func (d *definition) Is(target error) bool {
 	if d == target {
 		return true
@@ -252,30 +274,32 @@ func (d *definition) FormatError(err Error, s fmt.State, verb rune) {
 		return
 	}
 
+	scrub := presentScrubber(loadGlobalScrubber(), err)
+
 	switch verb {
 	case 'v':
 		switch {
 		case s.Flag('+'):
 			causes := err.UnwrapTree()
-			formatErrorDetails(err, s, "", len(causes) > 0)
+			formatErrorDetails(err, s, "", len(causes) > 0, scrub)
 
 			if len(causes) > 0 {
 				formatCausesHeader(s, "", len(causes))
-				formatNodes(causes, s, "  ")
+				formatNodes(causes, s, "  ", d.scrubber())
 			}
 		case s.Flag('#'):
 			if gs, ok := err.(fmt.GoStringer); ok {
 				_, _ = io.WriteString(s, gs.GoString())
 			} else {
-				_, _ = io.WriteString(s, err.Error())
+				_, _ = io.WriteString(s, scrub.apply(err.Error()))
 			}
 		default:
-			_, _ = io.WriteString(s, err.Error())
+			_, _ = io.WriteString(s, scrub.apply(err.Error()))
 		}
 	case 's':
-		_, _ = io.WriteString(s, err.Error())
+		_, _ = io.WriteString(s, scrub.apply(err.Error()))
 	case 'q':
-		_, _ = fmt.Fprintf(s, "%q", err.Error())
+		_, _ = fmt.Fprintf(s, "%q", scrub.apply(err.Error()))
 	}
 }
 
@@ -283,14 +307,7 @@ func (d *definition) MarshalErrorJSON(err Error) ([]byte, error) {
 	if d.jsonMarshaler != nil {
 		return d.jsonMarshaler(err)
 	}
-
-	return json.Marshal(jsonErrorData{
-		Message: err.Error(),
-		Kind:    string(err.Kind()),
-		Fields:  err.Fields(),
-		Stack:   err.Stack(),
-		Causes:  err.UnwrapTree(),
-	})
+	return marshalErrorJSON(err, loadGlobalScrubber())
 }
 
 func (d *definition) MakeErrorLogValue(err Error) slog.Value {
@@ -299,7 +316,7 @@ func (d *definition) MakeErrorLogValue(err Error) slog.Value {
 	}
 
 	attrs := make([]slog.Attr, 0, 5)
-	attrs = append(attrs, slog.String("message", err.Error()))
+	attrs = append(attrs, slog.String("message", presentScrubber(loadGlobalScrubber(), err).apply(err.Error())))
 
 	if err.Kind() != "" {
 		attrs = append(attrs, slog.String("kind", string(err.Kind())))
@@ -315,14 +332,36 @@ func (d *definition) MakeErrorLogValue(err Error) slog.Value {
 	return slog.GroupValue(attrs...)
 }
 
+// scrubber returns the scrubber inherited by the causes of errors of this definition.
+func (d *definition) scrubber() Scrubber {
+	return ChainScrubbers(loadGlobalScrubber(), d.messageScrubber)
+}
+
 func (d *definition) BuildCauseTree(err Error) Nodes {
 	visited := make(map[uintptr]uintptr)
 	nodes := buildNodes(err.Unwrap(), visited)
 	return nodes
 }
 
-func formatErrorDetails(err Error, s io.Writer, indent string, hasCauses bool) {
-	_, _ = io.WriteString(s, err.Error())
+// marshalErrorJSON marshals err with the scrubber inherited from its ancestors.
+func marshalErrorJSON(err Error, scrub Scrubber) ([]byte, error) {
+	return json.Marshal(jsonErrorData{
+		Message: presentScrubber(scrub, err).apply(err.Error()),
+		Kind:    string(err.Kind()),
+		Fields:  err.Fields(),
+		Stack:   err.Stack(),
+		Causes:  newJSONNodes(err.UnwrapTree(), ChainScrubbers(scrub, ownScrubber(err))),
+	})
+}
+
+// presentScrubber returns the scrubber for the message of err, which embeds
+// the messages of its causes when created by Wrap or Wrapf.
+func presentScrubber(inherited Scrubber, err error) Scrubber {
+	return ChainScrubbers(inherited, causeScrubber(err))
+}
+
+func formatErrorDetails(err Error, s io.Writer, indent string, hasCauses bool, scrub Scrubber) {
+	_, _ = io.WriteString(s, scrub.apply(err.Error()))
 
 	hasDetails := err.Kind() != "" || err.Fields().Len() > 0 || err.Stack().Len() > 0
 	if hasDetails || hasCauses {
@@ -349,7 +388,7 @@ func formatErrorDetails(err Error, s io.Writer, indent string, hasCauses bool) {
 			_, _ = io.WriteString(s, k.String())
 			_, _ = io.WriteString(s, ": ")
 
-			valueStr := fmt.Sprintf("%+v", v.Value())
+			valueStr := fmt.Sprintf("%+v", PresentFieldValue(k, v))
 			if strings.Contains(valueStr, "\n") {
 				_, _ = io.WriteString(s, "|\n")
 				for line := range strings.SplitSeq(valueStr, "\n") {
@@ -406,7 +445,7 @@ func formatCausesHeader(s io.Writer, indent string, count int) {
 	_, _ = io.WriteString(s, ")")
 }
 
-func formatNodes(nodes []*Node, s io.Writer, indent string) {
+func formatNodes(nodes []*Node, s io.Writer, indent string, scrub Scrubber) {
 	for i, node := range nodes {
 		_, _ = io.WriteString(s, "\n")
 		_, _ = io.WriteString(s, indent)
@@ -414,10 +453,11 @@ func formatNodes(nodes []*Node, s io.Writer, indent string) {
 		_, _ = io.WriteString(s, strconv.Itoa(i+1))
 		_, _ = io.WriteString(s, "] ")
 
+		msgScrub := presentScrubber(scrub, node.Error)
 		if err, ok := node.Error.(Error); ok {
-			formatErrorDetails(err, s, indent+"    ", len(node.Causes) > 0)
+			formatErrorDetails(err, s, indent+"    ", len(node.Causes) > 0, msgScrub)
 		} else {
-			_, _ = io.WriteString(s, node.Error.Error())
+			_, _ = io.WriteString(s, msgScrub.apply(node.Error.Error()))
 
 			if len(node.Causes) > 0 {
 				_, _ = io.WriteString(s, "\n")
@@ -428,7 +468,7 @@ func formatNodes(nodes []*Node, s io.Writer, indent string) {
 
 		if len(node.Causes) > 0 {
 			formatCausesHeader(s, indent+"    ", len(node.Causes))
-			formatNodes(node.Causes, s, indent+"    ")
+			formatNodes(node.Causes, s, indent+"    ", ChainScrubbers(scrub, ownScrubber(node.Error)))
 		}
 	}
 }
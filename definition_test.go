@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/shiwano/errdef"
@@ -497,6 +498,51 @@ func TestDefinition_Recover(t *testing.T) {
 		}
 	})
 
+	t.Run("runtime error marked as bug", func(t *testing.T) {
+		def := errdef.Define("panic_error")
+		err := def.Recover(func() error {
+			var s []int
+			_ = s[1]
+			return nil
+		})
+
+		if !errors.Is(err, def) {
+			t.Fatal("want error to be wrapped by the definition")
+		}
+		if !errdef.IsBug(err) {
+			t.Error("want runtime error panic to be marked as bug")
+		}
+		if errdef.IsBug(def) {
+			t.Error("want definition not to be modified")
+		}
+	})
+
+	t.Run("user panic not marked as bug", func(t *testing.T) {
+		def := errdef.Define("panic_error")
+		err := def.Recover(func() error {
+			panic("user panic")
+		})
+
+		if errdef.IsBug(err) {
+			t.Error("want user panic not to be marked as bug")
+		}
+	})
+
+	t.Run("re-panics http.ErrAbortHandler", func(t *testing.T) {
+		def := errdef.Define("panic_error")
+
+		defer func() {
+			if r := recover(); r != http.ErrAbortHandler {
+				t.Errorf("want re-panic with http.ErrAbortHandler, got %v", r)
+			}
+		}()
+
+		_ = def.Recover(func() error {
+			panic(http.ErrAbortHandler)
+		})
+		t.Error("want Recover to re-panic")
+	})
+
 	t.Run("nested recover", func(t *testing.T) {
 		defOuter := errdef.Define("outer_panic")
 		defInner := errdef.Define("inner_panic")
@@ -34,8 +34,13 @@ func Define(kind Kind, opts ...Option) Definition {
 // The name string is used as the key when an error's fields are serialized
 // (e.g., to JSON). To avoid ambiguity in logs and other serialized representations,
 // it is strongly recommended to use a unique name for each defined field.
-func DefineField[T any](name string) (FieldConstructor[T], FieldExtractor[T]) {
+//
+// FieldOptions such as Sensitive can be given to control how the field is presented.
+func DefineField[T any](name string, opts ...FieldOption) (FieldConstructor[T], FieldExtractor[T]) {
 	k := &fieldKey[T]{name: name}
+	for _, opt := range opts {
+		opt.applyFieldOption(&k.fieldKeyConfig)
+	}
 	ctor := func(value T) Option {
 		return &field[T]{key: k, value: value}
 	}
@@ -0,0 +1,775 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.32.1
+// source: errdef.proto
+
+package errdefpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Error is a serialized errdef error.
+type Error struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The error message.
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// The kind of the error.
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The fields of the error, keyed by field name.
+	Fields map[string]*FieldValue `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The stack trace where the error was created, innermost frame first.
+	Stack []*StackFrame `protobuf:"bytes,4,rep,name=stack,proto3" json:"stack,omitempty"`
+	// The wrapped errors.
+	Causes        []*Cause `protobuf:"bytes,5,rep,name=causes,proto3" json:"causes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_errdef_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_errdef_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_errdef_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Error) GetFields() map[string]*FieldValue {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Error) GetStack() []*StackFrame {
+	if x != nil {
+		return x.Stack
+	}
+	return nil
+}
+
+func (x *Error) GetCauses() []*Cause {
+	if x != nil {
+		return x.Causes
+	}
+	return nil
+}
+
+// Cause is a wrapped error: either an errdef error with a kind, or another
+// error with its Go type name (e.g., "*errors.errorString").
+type Cause struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Fields        map[string]*FieldValue `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Stack         []*StackFrame          `protobuf:"bytes,5,rep,name=stack,proto3" json:"stack,omitempty"`
+	Causes        []*Cause               `protobuf:"bytes,6,rep,name=causes,proto3" json:"causes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cause) Reset() {
+	*x = Cause{}
+	mi := &file_errdef_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cause) ProtoMessage() {}
+
+func (x *Cause) ProtoReflect() protoreflect.Message {
+	mi := &file_errdef_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cause.ProtoReflect.Descriptor instead.
+func (*Cause) Descriptor() ([]byte, []int) {
+	return file_errdef_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Cause) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Cause) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Cause) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Cause) GetFields() map[string]*FieldValue {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Cause) GetStack() []*StackFrame {
+	if x != nil {
+		return x.Stack
+	}
+	return nil
+}
+
+func (x *Cause) GetCauses() []*Cause {
+	if x != nil {
+		return x.Causes
+	}
+	return nil
+}
+
+// StackFrame is a single frame in a stack trace.
+type StackFrame struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Func          string                 `protobuf:"bytes,1,opt,name=func,proto3" json:"func,omitempty"`
+	File          string                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Line          int32                  `protobuf:"varint,3,opt,name=line,proto3" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StackFrame) Reset() {
+	*x = StackFrame{}
+	mi := &file_errdef_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StackFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StackFrame) ProtoMessage() {}
+
+func (x *StackFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_errdef_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StackFrame.ProtoReflect.Descriptor instead.
+func (*StackFrame) Descriptor() ([]byte, []int) {
+	return file_errdef_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StackFrame) GetFunc() string {
+	if x != nil {
+		return x.Func
+	}
+	return ""
+}
+
+func (x *StackFrame) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *StackFrame) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+// FieldValue is the value of a field.
+type FieldValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*FieldValue_StringValue
+	//	*FieldValue_IntValue
+	//	*FieldValue_UintValue
+	//	*FieldValue_DoubleValue
+	//	*FieldValue_BoolValue
+	//	*FieldValue_BytesValue
+	//	*FieldValue_DurationValue
+	//	*FieldValue_TimestampValue
+	//	*FieldValue_ListValue
+	//	*FieldValue_MapValue
+	//	*FieldValue_JsonValue
+	//	*FieldValue_Redacted
+	Value         isFieldValue_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldValue) Reset() {
+	*x = FieldValue{}
+	mi := &file_errdef_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldValue) ProtoMessage() {}
+
+func (x *FieldValue) ProtoReflect() protoreflect.Message {
+	mi := &file_errdef_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldValue.ProtoReflect.Descriptor instead.
+func (*FieldValue) Descriptor() ([]byte, []int) {
+	return file_errdef_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FieldValue) GetValue() isFieldValue_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *FieldValue) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Value.(*FieldValue_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *FieldValue) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Value.(*FieldValue_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *FieldValue) GetUintValue() uint64 {
+	if x != nil {
+		if x, ok := x.Value.(*FieldValue_UintValue); ok {
+			return x.UintValue
+		}
+	}
+	return 0
+}
+
+func (x *FieldValue) GetDoubleValue() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*FieldValue_DoubleValue); ok {
+			return x.DoubleValue
+		}
+	}
+	return 0
+}
+
+func (x *FieldValue) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Value.(*FieldValue_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *FieldValue) GetBytesValue() []byte {
+	if x != nil {
+		if x, ok := x.Value.(*FieldValue_BytesValue); ok {
+			return x.BytesValue
+		}
+	}
+	return nil
+}
+
+func (x *FieldValue) GetDurationValue() *durationpb.Duration {
+	if x != nil {
+		if x, ok := x.Value.(*FieldValue_DurationValue); ok {
+			return x.DurationValue
+		}
+	}
+	return nil
+}
+
+func (x *FieldValue) GetTimestampValue() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.Value.(*FieldValue_TimestampValue); ok {
+			return x.TimestampValue
+		}
+	}
+	return nil
+}
+
+func (x *FieldValue) GetListValue() *ListValue {
+	if x != nil {
+		if x, ok := x.Value.(*FieldValue_ListValue); ok {
+			return x.ListValue
+		}
+	}
+	return nil
+}
+
+func (x *FieldValue) GetMapValue() *MapValue {
+	if x != nil {
+		if x, ok := x.Value.(*FieldValue_MapValue); ok {
+			return x.MapValue
+		}
+	}
+	return nil
+}
+
+func (x *FieldValue) GetJsonValue() []byte {
+	if x != nil {
+		if x, ok := x.Value.(*FieldValue_JsonValue); ok {
+			return x.JsonValue
+		}
+	}
+	return nil
+}
+
+func (x *FieldValue) GetRedacted() *Redacted {
+	if x != nil {
+		if x, ok := x.Value.(*FieldValue_Redacted); ok {
+			return x.Redacted
+		}
+	}
+	return nil
+}
+
+type isFieldValue_Value interface {
+	isFieldValue_Value()
+}
+
+type FieldValue_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type FieldValue_IntValue struct {
+	IntValue int64 `protobuf:"varint,2,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type FieldValue_UintValue struct {
+	// Unsigned integers that do not fit in int_value.
+	UintValue uint64 `protobuf:"varint,3,opt,name=uint_value,json=uintValue,proto3,oneof"`
+}
+
+type FieldValue_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,4,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type FieldValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,5,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type FieldValue_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,6,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+type FieldValue_DurationValue struct {
+	DurationValue *durationpb.Duration `protobuf:"bytes,7,opt,name=duration_value,json=durationValue,proto3,oneof"`
+}
+
+type FieldValue_TimestampValue struct {
+	TimestampValue *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=timestamp_value,json=timestampValue,proto3,oneof"`
+}
+
+type FieldValue_ListValue struct {
+	ListValue *ListValue `protobuf:"bytes,9,opt,name=list_value,json=listValue,proto3,oneof"`
+}
+
+type FieldValue_MapValue struct {
+	MapValue *MapValue `protobuf:"bytes,10,opt,name=map_value,json=mapValue,proto3,oneof"`
+}
+
+type FieldValue_JsonValue struct {
+	// The JSON encoding of values of other types, such as structs.
+	JsonValue []byte `protobuf:"bytes,11,opt,name=json_value,json=jsonValue,proto3,oneof"`
+}
+
+type FieldValue_Redacted struct {
+	// Set if the value was redacted before serialization.
+	Redacted *Redacted `protobuf:"bytes,12,opt,name=redacted,proto3,oneof"`
+}
+
+func (*FieldValue_StringValue) isFieldValue_Value() {}
+
+func (*FieldValue_IntValue) isFieldValue_Value() {}
+
+func (*FieldValue_UintValue) isFieldValue_Value() {}
+
+func (*FieldValue_DoubleValue) isFieldValue_Value() {}
+
+func (*FieldValue_BoolValue) isFieldValue_Value() {}
+
+func (*FieldValue_BytesValue) isFieldValue_Value() {}
+
+func (*FieldValue_DurationValue) isFieldValue_Value() {}
+
+func (*FieldValue_TimestampValue) isFieldValue_Value() {}
+
+func (*FieldValue_ListValue) isFieldValue_Value() {}
+
+func (*FieldValue_MapValue) isFieldValue_Value() {}
+
+func (*FieldValue_JsonValue) isFieldValue_Value() {}
+
+func (*FieldValue_Redacted) isFieldValue_Value() {}
+
+// ListValue is a list of field values.
+type ListValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*FieldValue          `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListValue) Reset() {
+	*x = ListValue{}
+	mi := &file_errdef_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListValue) ProtoMessage() {}
+
+func (x *ListValue) ProtoReflect() protoreflect.Message {
+	mi := &file_errdef_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListValue.ProtoReflect.Descriptor instead.
+func (*ListValue) Descriptor() ([]byte, []int) {
+	return file_errdef_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListValue) GetValues() []*FieldValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// MapValue is a map of field values keyed by strings.
+type MapValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        map[string]*FieldValue `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapValue) Reset() {
+	*x = MapValue{}
+	mi := &file_errdef_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapValue) ProtoMessage() {}
+
+func (x *MapValue) ProtoReflect() protoreflect.Message {
+	mi := &file_errdef_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapValue.ProtoReflect.Descriptor instead.
+func (*MapValue) Descriptor() ([]byte, []int) {
+	return file_errdef_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MapValue) GetValues() map[string]*FieldValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Redacted marks a value that was redacted before serialization.
+type Redacted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Redacted) Reset() {
+	*x = Redacted{}
+	mi := &file_errdef_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Redacted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Redacted) ProtoMessage() {}
+
+func (x *Redacted) ProtoReflect() protoreflect.Message {
+	mi := &file_errdef_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Redacted.ProtoReflect.Descriptor instead.
+func (*Redacted) Descriptor() ([]byte, []int) {
+	return file_errdef_proto_rawDescGZIP(), []int{6}
+}
+
+var File_errdef_proto protoreflect.FileDescriptor
+
+const file_errdef_proto_rawDesc = "" +
+	"\n" +
+	"\ferrdef.proto\x12\terrdef.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x94\x02\n" +
+	"\x05Error\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x124\n" +
+	"\x06fields\x18\x03 \x03(\v2\x1c.errdef.v1.Error.FieldsEntryR\x06fields\x12+\n" +
+	"\x05stack\x18\x04 \x03(\v2\x15.errdef.v1.StackFrameR\x05stack\x12(\n" +
+	"\x06causes\x18\x05 \x03(\v2\x10.errdef.v1.CauseR\x06causes\x1aP\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.errdef.v1.FieldValueR\x05value:\x028\x01\"\xa8\x02\n" +
+	"\x05Cause\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x124\n" +
+	"\x06fields\x18\x04 \x03(\v2\x1c.errdef.v1.Cause.FieldsEntryR\x06fields\x12+\n" +
+	"\x05stack\x18\x05 \x03(\v2\x15.errdef.v1.StackFrameR\x05stack\x12(\n" +
+	"\x06causes\x18\x06 \x03(\v2\x10.errdef.v1.CauseR\x06causes\x1aP\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.errdef.v1.FieldValueR\x05value:\x028\x01\"H\n" +
+	"\n" +
+	"StackFrame\x12\x12\n" +
+	"\x04func\x18\x01 \x01(\tR\x04func\x12\x12\n" +
+	"\x04file\x18\x02 \x01(\tR\x04file\x12\x12\n" +
+	"\x04line\x18\x03 \x01(\x05R\x04line\"\xad\x04\n" +
+	"\n" +
+	"FieldValue\x12#\n" +
+	"\fstring_value\x18\x01 \x01(\tH\x00R\vstringValue\x12\x1d\n" +
+	"\tint_value\x18\x02 \x01(\x03H\x00R\bintValue\x12\x1f\n" +
+	"\n" +
+	"uint_value\x18\x03 \x01(\x04H\x00R\tuintValue\x12#\n" +
+	"\fdouble_value\x18\x04 \x01(\x01H\x00R\vdoubleValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x05 \x01(\bH\x00R\tboolValue\x12!\n" +
+	"\vbytes_value\x18\x06 \x01(\fH\x00R\n" +
+	"bytesValue\x12B\n" +
+	"\x0eduration_value\x18\a \x01(\v2\x19.google.protobuf.DurationH\x00R\rdurationValue\x12E\n" +
+	"\x0ftimestamp_value\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x0etimestampValue\x125\n" +
+	"\n" +
+	"list_value\x18\t \x01(\v2\x14.errdef.v1.ListValueH\x00R\tlistValue\x122\n" +
+	"\tmap_value\x18\n" +
+	" \x01(\v2\x13.errdef.v1.MapValueH\x00R\bmapValue\x12\x1f\n" +
+	"\n" +
+	"json_value\x18\v \x01(\fH\x00R\tjsonValue\x121\n" +
+	"\bredacted\x18\f \x01(\v2\x13.errdef.v1.RedactedH\x00R\bredactedB\a\n" +
+	"\x05value\":\n" +
+	"\tListValue\x12-\n" +
+	"\x06values\x18\x01 \x03(\v2\x15.errdef.v1.FieldValueR\x06values\"\x95\x01\n" +
+	"\bMapValue\x127\n" +
+	"\x06values\x18\x01 \x03(\v2\x1f.errdef.v1.MapValue.ValuesEntryR\x06values\x1aP\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.errdef.v1.FieldValueR\x05value:\x028\x01\"\n" +
+	"\n" +
+	"\bRedactedB-Z+github.com/shiwano/errdef/errdefpb;errdefpbb\x06proto3"
+
+var (
+	file_errdef_proto_rawDescOnce sync.Once
+	file_errdef_proto_rawDescData []byte
+)
+
+func file_errdef_proto_rawDescGZIP() []byte {
+	file_errdef_proto_rawDescOnce.Do(func() {
+		file_errdef_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_errdef_proto_rawDesc), len(file_errdef_proto_rawDesc)))
+	})
+	return file_errdef_proto_rawDescData
+}
+
+var file_errdef_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_errdef_proto_goTypes = []any{
+	(*Error)(nil),                 // 0: errdef.v1.Error
+	(*Cause)(nil),                 // 1: errdef.v1.Cause
+	(*StackFrame)(nil),            // 2: errdef.v1.StackFrame
+	(*FieldValue)(nil),            // 3: errdef.v1.FieldValue
+	(*ListValue)(nil),             // 4: errdef.v1.ListValue
+	(*MapValue)(nil),              // 5: errdef.v1.MapValue
+	(*Redacted)(nil),              // 6: errdef.v1.Redacted
+	nil,                           // 7: errdef.v1.Error.FieldsEntry
+	nil,                           // 8: errdef.v1.Cause.FieldsEntry
+	nil,                           // 9: errdef.v1.MapValue.ValuesEntry
+	(*durationpb.Duration)(nil),   // 10: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+}
+var file_errdef_proto_depIdxs = []int32{
+	7,  // 0: errdef.v1.Error.fields:type_name -> errdef.v1.Error.FieldsEntry
+	2,  // 1: errdef.v1.Error.stack:type_name -> errdef.v1.StackFrame
+	1,  // 2: errdef.v1.Error.causes:type_name -> errdef.v1.Cause
+	8,  // 3: errdef.v1.Cause.fields:type_name -> errdef.v1.Cause.FieldsEntry
+	2,  // 4: errdef.v1.Cause.stack:type_name -> errdef.v1.StackFrame
+	1,  // 5: errdef.v1.Cause.causes:type_name -> errdef.v1.Cause
+	10, // 6: errdef.v1.FieldValue.duration_value:type_name -> google.protobuf.Duration
+	11, // 7: errdef.v1.FieldValue.timestamp_value:type_name -> google.protobuf.Timestamp
+	4,  // 8: errdef.v1.FieldValue.list_value:type_name -> errdef.v1.ListValue
+	5,  // 9: errdef.v1.FieldValue.map_value:type_name -> errdef.v1.MapValue
+	6,  // 10: errdef.v1.FieldValue.redacted:type_name -> errdef.v1.Redacted
+	3,  // 11: errdef.v1.ListValue.values:type_name -> errdef.v1.FieldValue
+	9,  // 12: errdef.v1.MapValue.values:type_name -> errdef.v1.MapValue.ValuesEntry
+	3,  // 13: errdef.v1.Error.FieldsEntry.value:type_name -> errdef.v1.FieldValue
+	3,  // 14: errdef.v1.Cause.FieldsEntry.value:type_name -> errdef.v1.FieldValue
+	3,  // 15: errdef.v1.MapValue.ValuesEntry.value:type_name -> errdef.v1.FieldValue
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_errdef_proto_init() }
+func file_errdef_proto_init() {
+	if File_errdef_proto != nil {
+		return
+	}
+	file_errdef_proto_msgTypes[3].OneofWrappers = []any{
+		(*FieldValue_StringValue)(nil),
+		(*FieldValue_IntValue)(nil),
+		(*FieldValue_UintValue)(nil),
+		(*FieldValue_DoubleValue)(nil),
+		(*FieldValue_BoolValue)(nil),
+		(*FieldValue_BytesValue)(nil),
+		(*FieldValue_DurationValue)(nil),
+		(*FieldValue_TimestampValue)(nil),
+		(*FieldValue_ListValue)(nil),
+		(*FieldValue_MapValue)(nil),
+		(*FieldValue_JsonValue)(nil),
+		(*FieldValue_Redacted)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_errdef_proto_rawDesc), len(file_errdef_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_errdef_proto_goTypes,
+		DependencyIndexes: file_errdef_proto_depIdxs,
+		MessageInfos:      file_errdef_proto_msgTypes,
+	}.Build()
+	File_errdef_proto = out.File
+	file_errdef_proto_goTypes = nil
+	file_errdef_proto_depIdxs = nil
+}
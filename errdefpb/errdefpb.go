@@ -0,0 +1,363 @@
+// Package errdefpb provides the Protocol Buffers wire format for errdef errors.
+//
+// The schema is defined in errdef.proto (package errdef.v1): an Error message
+// with its fields, stack trace, and causes, where field values are typed by a
+// FieldValue oneof. Encode and Decode convert between the messages and the
+// data of the marshaler and unmarshaler packages:
+//
+//	m := errdefpb.NewMarshaler()
+//	msg, err := m.Marshal(err) // *errdefpb.Error
+//
+//	u := errdefpb.NewUnmarshaler(resolver)
+//	restored, err := u.Unmarshal(msg)
+//
+// Field values are encoded by their Go types: integers, floats, booleans,
+// strings, and byte slices as scalars, time.Duration and time.Time as the
+// well-known Duration and Timestamp types, slices and string-keyed maps as
+// lists and maps, and other types (e.g., structs) as JSON. Redacted values
+// are encoded as the Redacted marker.
+package errdefpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative errdef.proto
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/marshaler"
+	"github.com/shiwano/errdef/resolver"
+	"github.com/shiwano/errdef/unmarshaler"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	_ marshaler.Encoder[*Error]   = Encode
+	_ unmarshaler.Decoder[*Error] = Decode
+	_ marshaler.Encoder[[]byte]   = EncodeBinary
+	_ unmarshaler.Decoder[[]byte] = DecodeBinary
+
+	redactedStr = errdef.Redact[any](nil).String()
+)
+
+// NewMarshaler creates a new Marshaler that marshals errors into Error messages.
+func NewMarshaler(opts ...marshaler.Option) *marshaler.Marshaler[*Error] {
+	return marshaler.New(Encode, opts...)
+}
+
+// NewUnmarshaler creates a new Unmarshaler that unmarshals errors from Error messages.
+func NewUnmarshaler(r resolver.Resolver, opts ...unmarshaler.Option) *unmarshaler.Unmarshaler[*Error] {
+	return unmarshaler.New(r, Decode, opts...)
+}
+
+// Encode converts the encoded data of an error into an Error message.
+// It returns an error if a field value cannot be encoded (e.g., a channel).
+func Encode(data *marshaler.EncodedData) (*Error, error) {
+	fields, err := encodeFields(data.Fields)
+	if err != nil {
+		return nil, err
+	}
+	causes, err := encodeCauses(data.Causes)
+	if err != nil {
+		return nil, err
+	}
+	return &Error{
+		Message: data.Message,
+		Kind:    string(data.Kind),
+		Fields:  fields,
+		Stack:   encodeStack(data.Stack),
+		Causes:  causes,
+	}, nil
+}
+
+// Decode converts an Error message into the data to be unmarshaled.
+func Decode(msg *Error) (*unmarshaler.DecodedData, error) {
+	fields, err := decodeFields(msg.GetFields())
+	if err != nil {
+		return nil, err
+	}
+	causes, err := decodeCauses(msg.GetCauses())
+	if err != nil {
+		return nil, err
+	}
+	return &unmarshaler.DecodedData{
+		Message: msg.GetMessage(),
+		Kind:    errdef.Kind(msg.GetKind()),
+		Fields:  fields,
+		Stack:   decodeStack(msg.GetStack()),
+		Causes:  causes,
+	}, nil
+}
+
+// EncodeBinary converts the encoded data of an error into the binary wire
+// format of an Error message.
+func EncodeBinary(data *marshaler.EncodedData) ([]byte, error) {
+	msg, err := Encode(data)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(msg)
+}
+
+// DecodeBinary converts the binary wire format of an Error message into the
+// data to be unmarshaled.
+func DecodeBinary(data []byte) (*unmarshaler.DecodedData, error) {
+	var msg Error
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return nil, err
+	}
+	return Decode(&msg)
+}
+
+func encodeCauses(causes []*marshaler.EncodedData) ([]*Cause, error) {
+	if len(causes) == 0 {
+		return nil, nil
+	}
+	result := make([]*Cause, len(causes))
+	for i, c := range causes {
+		fields, err := encodeFields(c.Fields)
+		if err != nil {
+			return nil, err
+		}
+		nested, err := encodeCauses(c.Causes)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = &Cause{
+			Message: c.Message,
+			Kind:    string(c.Kind),
+			Type:    c.Type,
+			Fields:  fields,
+			Stack:   encodeStack(c.Stack),
+			Causes:  nested,
+		}
+	}
+	return result, nil
+}
+
+func decodeCauses(causes []*Cause) ([]*unmarshaler.DecodedData, error) {
+	if len(causes) == 0 {
+		return nil, nil
+	}
+	result := make([]*unmarshaler.DecodedData, len(causes))
+	for i, c := range causes {
+		fields, err := decodeFields(c.GetFields())
+		if err != nil {
+			return nil, err
+		}
+		nested, err := decodeCauses(c.GetCauses())
+		if err != nil {
+			return nil, err
+		}
+		result[i] = &unmarshaler.DecodedData{
+			Message: c.GetMessage(),
+			Kind:    errdef.Kind(c.GetKind()),
+			Type:    c.GetType(),
+			Fields:  fields,
+			Stack:   decodeStack(c.GetStack()),
+			Causes:  nested,
+		}
+	}
+	return result, nil
+}
+
+func encodeStack(frames []errdef.Frame) []*StackFrame {
+	if len(frames) == 0 {
+		return nil
+	}
+	result := make([]*StackFrame, len(frames))
+	for i, f := range frames {
+		result[i] = &StackFrame{Func: f.Func, File: f.File, Line: int32(f.Line)}
+	}
+	return result
+}
+
+func decodeStack(frames []*StackFrame) []errdef.Frame {
+	if len(frames) == 0 {
+		return nil
+	}
+	result := make([]errdef.Frame, len(frames))
+	for i, f := range frames {
+		result[i] = errdef.Frame{Func: f.GetFunc(), File: f.GetFile(), Line: int(f.GetLine())}
+	}
+	return result
+}
+
+func encodeFields(fields map[string]any) (map[string]*FieldValue, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]*FieldValue, len(fields))
+	for name, value := range fields {
+		fv, err := newFieldValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("errdefpb: field %q: %w", name, err)
+		}
+		result[name] = fv
+	}
+	return result, nil
+}
+
+func decodeFields(fields map[string]*FieldValue) (map[string]any, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]any, len(fields))
+	for name, fv := range fields {
+		value, err := fv.value()
+		if err != nil {
+			return nil, fmt.Errorf("errdefpb: field %q: %w", name, err)
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// newFieldValue returns the FieldValue of v, choosing the variant by the type of v.
+func newFieldValue(v any) (*FieldValue, error) {
+	switch v := v.(type) {
+	case nil:
+		return &FieldValue{}, nil
+	case string:
+		if v == redactedStr {
+			return &FieldValue{Value: &FieldValue_Redacted{Redacted: &Redacted{}}}, nil
+		}
+		return &FieldValue{Value: &FieldValue_StringValue{StringValue: v}}, nil
+	case json.RawMessage:
+		return &FieldValue{Value: &FieldValue_JsonValue{JsonValue: v}}, nil
+	case []byte:
+		return &FieldValue{Value: &FieldValue_BytesValue{BytesValue: v}}, nil
+	case time.Duration:
+		return &FieldValue{Value: &FieldValue_DurationValue{DurationValue: durationpb.New(v)}}, nil
+	case time.Time:
+		return &FieldValue{Value: &FieldValue_TimestampValue{TimestampValue: timestamppb.New(v)}}, nil
+	}
+
+	// Values of derived types (e.g., slog.Level) are encoded by their kinds,
+	// so that the unmarshaler can convert them back into their types.
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return &FieldValue{Value: &FieldValue_BoolValue{BoolValue: rv.Bool()}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &FieldValue{Value: &FieldValue_IntValue{IntValue: rv.Int()}}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := rv.Uint(); u > math.MaxInt64 {
+			return &FieldValue{Value: &FieldValue_UintValue{UintValue: u}}, nil
+		}
+		return &FieldValue{Value: &FieldValue_IntValue{IntValue: int64(rv.Uint())}}, nil
+	case reflect.Float32, reflect.Float64:
+		return &FieldValue{Value: &FieldValue_DoubleValue{DoubleValue: rv.Float()}}, nil
+	case reflect.String:
+		return newFieldValue(rv.String())
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return &FieldValue{}, nil
+		}
+		return newFieldValue(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return &FieldValue{Value: &FieldValue_BytesValue{BytesValue: rv.Bytes()}}, nil
+		}
+		values := make([]*FieldValue, rv.Len())
+		for i := range rv.Len() {
+			fv, err := newFieldValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			values[i] = fv
+		}
+		return &FieldValue{Value: &FieldValue_ListValue{ListValue: &ListValue{Values: values}}}, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break // encoded as JSON
+		}
+		values := make(map[string]*FieldValue, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			fv, err := newFieldValue(iter.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+			values[iter.Key().String()] = fv
+		}
+		return &FieldValue{Value: &FieldValue_MapValue{MapValue: &MapValue{Values: values}}}, nil
+	case reflect.Complex64, reflect.Complex128, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return nil, fmt.Errorf("unsupported type %T", v)
+	}
+
+	if s, ok := v.(fmt.Stringer); ok && s.String() == redactedStr { // e.g., errdef.Redacted
+		return &FieldValue{Value: &FieldValue_Redacted{Redacted: &Redacted{}}}, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %T: %w", v, err)
+	}
+	return &FieldValue{Value: &FieldValue_JsonValue{JsonValue: data}}, nil
+}
+
+// value returns the Go value of the field value in the form the unmarshaler
+// converts into field types: int64, uint64, float64, []any, map[string]any, etc.
+func (x *FieldValue) value() (any, error) {
+	switch v := x.GetValue().(type) {
+	case nil:
+		return nil, nil
+	case *FieldValue_StringValue:
+		return v.StringValue, nil
+	case *FieldValue_IntValue:
+		return v.IntValue, nil
+	case *FieldValue_UintValue:
+		return v.UintValue, nil
+	case *FieldValue_DoubleValue:
+		return v.DoubleValue, nil
+	case *FieldValue_BoolValue:
+		return v.BoolValue, nil
+	case *FieldValue_BytesValue:
+		return v.BytesValue, nil
+	case *FieldValue_DurationValue:
+		if err := v.DurationValue.CheckValid(); err != nil {
+			return nil, err
+		}
+		return v.DurationValue.AsDuration(), nil
+	case *FieldValue_TimestampValue:
+		if err := v.TimestampValue.CheckValid(); err != nil {
+			return nil, err
+		}
+		return v.TimestampValue.AsTime(), nil
+	case *FieldValue_ListValue:
+		values := v.ListValue.GetValues()
+		result := make([]any, len(values))
+		for i, fv := range values {
+			value, err := fv.value()
+			if err != nil {
+				return nil, err
+			}
+			result[i] = value
+		}
+		return result, nil
+	case *FieldValue_MapValue:
+		values := v.MapValue.GetValues()
+		result := make(map[string]any, len(values))
+		for k, fv := range values {
+			value, err := fv.value()
+			if err != nil {
+				return nil, err
+			}
+			result[k] = value
+		}
+		return result, nil
+	case *FieldValue_JsonValue:
+		var value any
+		if err := json.Unmarshal(v.JsonValue, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case *FieldValue_Redacted:
+		return redactedStr, nil
+	}
+	return nil, fmt.Errorf("unknown field value type %T", x.GetValue())
+}
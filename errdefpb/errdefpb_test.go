@@ -0,0 +1,217 @@
+package errdefpb_test
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/errdefpb"
+	"github.com/shiwano/errdef/marshaler"
+	"github.com/shiwano/errdef/resolver"
+	"github.com/shiwano/errdef/unmarshaler"
+	"google.golang.org/protobuf/proto"
+)
+
+type address struct {
+	City string `json:"city"`
+}
+
+var (
+	errNotFound = errdef.Define("not_found", errdef.HTTPStatus(404), errdef.LogLevel(slog.LevelWarn))
+	errInternal = errdef.Define("internal")
+
+	userID, userIDFrom     = errdef.DefineField[string]("user_id")
+	count, countFrom       = errdef.DefineField[int]("count")
+	ratio, ratioFrom       = errdef.DefineField[float32]("ratio")
+	big, bigFrom           = errdef.DefineField[uint64]("big")
+	timeout, timeoutFrom   = errdef.DefineField[time.Duration]("timeout")
+	at, atFrom             = errdef.DefineField[time.Time]("at")
+	tags, tagsFrom         = errdef.DefineField[[]string]("tags")
+	limits, limitsFrom     = errdef.DefineField[map[string]int]("limits")
+	addr, addrFrom         = errdef.DefineField[address]("addr")
+	payload, payloadFrom   = errdef.DefineField[[]byte]("payload")
+	password, passwordFrom = errdef.DefineField[string]("password", errdef.Sensitive())
+)
+
+func TestRoundTrip(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	orig := errNotFound.WithOptions(
+		userID("u1"),
+		count(3),
+		ratio(0.5),
+		big(math.MaxUint64),
+		timeout(2*time.Second),
+		at(now),
+		tags([]string{"a", "b"}),
+		limits(map[string]int{"x": 1}),
+		addr(address{City: "Tokyo"}),
+		payload([]byte{0, 1, 2}),
+		password("secret"),
+	).Wrapf(errInternal.Wrap(io.EOF), "user not found").(errdef.Error)
+
+	msg, err := errdefpb.NewMarshaler().Marshal(orig)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("failed to marshal proto: %v", err)
+	}
+
+	r := resolver.New(errNotFound, errInternal)
+	u := unmarshaler.New(r, errdefpb.DecodeBinary,
+		unmarshaler.WithBuiltinFields(),
+		unmarshaler.WithStandardSentinelErrors(),
+		unmarshaler.WithCustomFields(
+			userID.Key(), count.Key(), ratio.Key(), big.Key(), timeout.Key(), at.Key(),
+			tags.Key(), limits.Key(), addr.Key(), payload.Key(), password.Key(),
+		),
+	)
+	restored, err := u.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if restored.Error() != orig.Error() {
+		t.Errorf("want message %q, got %q", orig.Error(), restored.Error())
+	}
+	if restored.Kind() != "not_found" {
+		t.Errorf("want kind %q, got %q", "not_found", restored.Kind())
+	}
+	if !reflect.DeepEqual(restored.Stack().Frames(), orig.Stack().Frames()) {
+		t.Errorf("want stack %v, got %v", orig.Stack().Frames(), restored.Stack().Frames())
+	}
+	if !errors.Is(restored, io.EOF) {
+		t.Error("want restored error to wrap io.EOF")
+	}
+
+	check := func(name string, got, want any, ok bool) {
+		t.Helper()
+		if !ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: want %v, got %v (ok=%v)", name, want, got, ok)
+		}
+	}
+	status, ok := errdef.HTTPStatusFrom(restored)
+	check("http_status", status, 404, ok)
+	level, ok := errdef.LogLevelFrom(restored)
+	check("log_level", level, slog.LevelWarn, ok)
+	v1, ok := userIDFrom(restored)
+	check("user_id", v1, "u1", ok)
+	v2, ok := countFrom(restored)
+	check("count", v2, 3, ok)
+	v3, ok := ratioFrom(restored)
+	check("ratio", v3, float32(0.5), ok)
+	v4, ok := bigFrom(restored)
+	check("big", v4, uint64(math.MaxUint64), ok)
+	v5, ok := timeoutFrom(restored)
+	check("timeout", v5, 2*time.Second, ok)
+	v6, ok := atFrom(restored)
+	check("at", v6, now, ok)
+	v7, ok := tagsFrom(restored)
+	check("tags", v7, []string{"a", "b"}, ok)
+	v8, ok := limitsFrom(restored)
+	check("limits", v8, map[string]int{"x": 1}, ok)
+	v9, ok := addrFrom(restored)
+	check("addr", v9, address{City: "Tokyo"}, ok)
+	v10, ok := payloadFrom(restored)
+	check("payload", v10, []byte{0, 1, 2}, ok)
+
+	if _, ok := passwordFrom(restored); ok {
+		t.Error("want redacted password to be unavailable")
+	}
+	for name, value := range restored.UnknownFields() {
+		if name == "password" && value != "[REDACTED]" {
+			t.Errorf("want redacted password, got %v", value)
+		}
+	}
+	if _, ok := msg.GetFields()["password"].GetValue().(*errdefpb.FieldValue_Redacted); !ok {
+		t.Errorf("want redacted marker, got %v", msg.GetFields()["password"])
+	}
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("causes", func(t *testing.T) {
+		err := errNotFound.Wrap(errInternal.Wrap(errors.New("boom"))).(errdef.Error)
+		msg, encErr := errdefpb.NewMarshaler(marshaler.WithoutStack()).Marshal(err)
+		if encErr != nil {
+			t.Fatalf("failed to encode: %v", encErr)
+		}
+
+		if len(msg.GetStack()) != 0 {
+			t.Errorf("want no stack, got %v", msg.GetStack())
+		}
+		if len(msg.GetCauses()) != 1 || msg.GetCauses()[0].GetKind() != "internal" {
+			t.Fatalf("want cause of kind internal, got %v", msg.GetCauses())
+		}
+		nested := msg.GetCauses()[0].GetCauses()
+		if len(nested) != 1 || nested[0].GetType() != "*errors.errorString" || nested[0].GetMessage() != "boom" {
+			t.Errorf("want nested *errors.errorString cause, got %v", nested)
+		}
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		ch, _ := errdef.DefineField[chan int]("ch")
+		err := errInternal.WithOptions(ch(make(chan int))).New("failed").(errdef.Error)
+		_, encErr := errdefpb.NewMarshaler().Marshal(err)
+		if encErr == nil || !strings.Contains(encErr.Error(), `field "ch"`) {
+			t.Errorf("want error for unsupported field, got %v", encErr)
+		}
+	})
+}
+
+func TestDecode(t *testing.T) {
+	msg := &errdefpb.Error{
+		Message: "failed",
+		Kind:    "internal",
+		Fields: map[string]*errdefpb.FieldValue{
+			"list": {Value: &errdefpb.FieldValue_ListValue{ListValue: &errdefpb.ListValue{
+				Values: []*errdefpb.FieldValue{{Value: &errdefpb.FieldValue_IntValue{IntValue: 1}}},
+			}}},
+			"json":     {Value: &errdefpb.FieldValue_JsonValue{JsonValue: []byte(`{"a":true}`)}},
+			"redacted": {Value: &errdefpb.FieldValue_Redacted{Redacted: &errdefpb.Redacted{}}},
+			"null":     {},
+		},
+		Stack: []*errdefpb.StackFrame{{Func: "main.main", File: "main.go", Line: 10}},
+		Causes: []*errdefpb.Cause{
+			{Message: "EOF", Type: "*errors.errorString"},
+		},
+	}
+
+	data, err := errdefpb.Decode(msg)
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	want := &unmarshaler.DecodedData{
+		Message: "failed",
+		Kind:    "internal",
+		Fields: map[string]any{
+			"list":     []any{int64(1)},
+			"json":     map[string]any{"a": true},
+			"redacted": "[REDACTED]",
+			"null":     nil,
+		},
+		Stack: []errdef.Frame{{Func: "main.main", File: "main.go", Line: 10}},
+		Causes: []*unmarshaler.DecodedData{
+			{Message: "EOF", Type: "*errors.errorString"},
+		},
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("want %+v, got %+v", want, data)
+	}
+
+	t.Run("invalid json", func(t *testing.T) {
+		msg := &errdefpb.Error{Fields: map[string]*errdefpb.FieldValue{
+			"json": {Value: &errdefpb.FieldValue_JsonValue{JsonValue: []byte(`{`)}},
+		}}
+		if _, err := errdefpb.Decode(msg); err == nil {
+			t.Error("want error for invalid json value")
+		}
+	})
+}
@@ -0,0 +1,8 @@
+module github.com/shiwano/errdef/errdefpb
+
+go 1.25.0
+
+require (
+	github.com/shiwano/errdef v0.0.0-20261018141422-4c78b1e5840b
+	google.golang.org/protobuf v1.36.10
+)
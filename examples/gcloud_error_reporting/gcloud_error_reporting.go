@@ -144,7 +144,7 @@ func filterGCloudFields(fields errdef.Fields) map[string]any {
 		if k.String() == "gcerr.http_request" || k.String() == "gcerr.user" {
 			continue
 		}
-		filtered[k.String()] = v.Value()
+		filtered[k.String()] = errdef.PresentFieldValue(k, v)
 	}
 	return filtered
 }
@@ -46,5 +46,6 @@ var (
 	ResourceType, ResourceTypeFrom = errdef.DefineField[string]("resource_type")
 
 	// ValidationErrors field stores field-level validation error messages.
-	ValidationErrors, ValidationErrorsFrom = errdef.DefineField[map[string]string]("validation_errors")
+	// It is marked as public so that it is included in error responses.
+	ValidationErrors, ValidationErrorsFrom = errdef.DefineField[map[string]string]("validation_errors", errdef.PublicField())
 )
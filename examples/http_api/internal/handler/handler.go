@@ -124,41 +124,31 @@ func (h *Handler) writeJSON(w http.ResponseWriter, status int, data any) {
 func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
 	ctx := r.Context()
 
-	// Extract structured error information
+	// Build a sanitized view of the error that is safe to expose
 	statusCode := errdef.HTTPStatusFrom.OrDefault(err, http.StatusInternalServerError)
-	traceID := errdef.TraceIDFrom.OrZero(err)
-
-	// Determine the error message to return
-	message := err.Error()
-	var errdefErr errdef.Error
-	if errors.As(err, &errdefErr) {
-		// If the error is not marked as public, use a generic message
-		if !errdef.IsPublic(err) {
-			message = "an internal error occurred"
-		}
+	view, ok := errdef.PublicView(err)
+	if !ok || view.Message == "" {
+		// No public error or message in the tree, use a generic message
+		view.Message = "an internal error occurred"
 	}
 
-	// Build error response
+	// Build error response from the message and public fields
 	resp := map[string]any{
-		"error": message,
+		"error": view.Message,
 	}
 
-	// Add kind if available
-	if errors.As(err, &errdefErr) {
+	// Add kind if available, preferring the kind of the public error
+	var errdefErr errdef.Error
+	if view.Kind != "" {
+		resp["kind"] = string(view.Kind)
+	} else if errors.As(err, &errdefErr) {
 		resp["kind"] = string(errdefErr.Kind())
 	}
-
-	// Add trace_id if available
-	if traceID != "" {
-		resp["trace_id"] = traceID
-	}
-
-	// Add validation errors if present
-	if validationErrs, ok := errdefs.ValidationErrorsFrom(err); ok {
-		resp["validation_errors"] = validationErrs
+	for name, value := range view.Fields {
+		resp[name] = value
 	}
 
-	// Add retry_after if present
+	// Represent retry_after in seconds
 	if retryAfter, ok := errdef.RetryAfterFrom(err); ok {
 		resp["retry_after"] = retryAfter.Seconds()
 	}
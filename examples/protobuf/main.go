@@ -67,7 +67,7 @@ func marshalProto(e errdef.Error) ([]byte, error) {
 	if e.Fields().Len() > 0 {
 		msg.Fields = make(map[string]*FieldValue)
 		for k, v := range e.Fields().All() {
-			fv, err := anyToFieldValue(v.Value())
+			fv, err := anyToFieldValue(errdef.PresentFieldValue(k, v))
 			if err != nil {
 				return nil, err
 			}
@@ -111,7 +111,7 @@ func nodeToCause(node *errdef.Node) (*Cause, error) {
 		if e.Fields().Len() > 0 {
 			cp.Fields = make(map[string]*FieldValue)
 			for k, v := range e.Fields().All() {
-				fv, err := anyToFieldValue(v.Value())
+				fv, err := anyToFieldValue(errdef.PresentFieldValue(k, v))
 				if err != nil {
 					return nil, err
 				}
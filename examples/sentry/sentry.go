@@ -64,7 +64,7 @@ func CaptureError(ctx context.Context, err error) bool {
 				if k.String() == "sentry.level" {
 					continue
 				}
-				fieldsData[k.String()] = v.Value()
+				fieldsData[k.String()] = errdef.PresentFieldValue(k, v)
 			}
 			if len(fieldsData) > 0 {
 				errorContext["fields"] = fieldsData
@@ -132,7 +132,7 @@ func buildCauseData(node *errdef.Node, depth int) map[string]any {
 			if k.String() == "sentry.level" {
 				continue
 			}
-			fieldsData[k.String()] = v.Value()
+			fieldsData[k.String()] = errdef.PresentFieldValue(k, v)
 		}
 		if len(fieldsData) > 0 {
 			data["fields"] = fieldsData
@@ -87,7 +87,7 @@ type fieldsMarshaler struct {
 
 func (m fieldsMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	for k, v := range m.fields.All() {
-		_ = enc.AddReflected(k.String(), v.Value())
+		_ = enc.AddReflected(k.String(), errdef.PresentFieldValue(k, v))
 	}
 	return nil
 }
@@ -21,6 +21,7 @@ var (
 	UserID, _ = errdef.DefineField[string]("user_id")
 	Email, _  = errdef.DefineField[errdef.Redacted[string]]("email")
 	Count, _  = errdef.DefineField[int]("count")
+	Token, _  = errdef.DefineField[string]("token", errdef.Sensitive())
 )
 
 var tests = []struct {
@@ -41,6 +42,23 @@ var tests = []struct {
 			"origin": nil,
 		},
 	},
+	{
+		name: "error with sensitive field",
+		err: ErrNotFound.WithOptions(
+			UserID("u123"),
+			Token("secret-token"),
+		).New("user not found"),
+		want: map[string]any{
+			"message": "user not found",
+			"kind":    "not_found",
+			"fields": map[string]any{
+				"user_id":     "u123",
+				"token":       "[REDACTED]",
+				"http_status": 404,
+			},
+			"origin": nil,
+		},
+	},
 	{
 		name: "error with redacted field",
 		err: ErrNotFound.WithOptions(
@@ -62,7 +62,7 @@ type fieldsMarshaler struct {
 
 func (m fieldsMarshaler) MarshalZerologObject(e *zerolog.Event) {
 	for k, v := range m.fields.All() {
-		e.Interface(k.String(), v.Value())
+		e.Interface(k.String(), errdef.PresentFieldValue(k, v))
 	}
 }
 
@@ -58,6 +58,12 @@ type (
 
 	fieldKey[T any] struct {
 		name string
+		fieldKeyConfig
+	}
+
+	fieldKeyConfig struct {
+		sensitive bool
+		public    bool
 	}
 
 	fieldValue[T any] struct {
@@ -129,7 +135,7 @@ func (f *fields) MarshalJSON() ([]byte, error) {
 	for k, v := range f.All() {
 		// If multiple fields have the same name,
 		// the last one in insertion order will be used.
-		fields[k.String()] = v.Value()
+		fields[k.String()] = PresentFieldValue(k, v)
 	}
 	return json.Marshal(fields)
 }
@@ -139,7 +145,7 @@ func (f *fields) LogValue() slog.Value {
 	for k, v := range f.All() {
 		// If multiple fields have the same name,
 		// the last one in insertion order will be used.
-		attrs = append(attrs, slog.Any(k.String(), v.Value()))
+		attrs = append(attrs, slog.Any(k.String(), PresentFieldValue(k, v)))
 	}
 	return slog.GroupValue(attrs...)
 }
@@ -166,6 +172,16 @@ func (k *fieldKey[T]) String() string {
 	return k.name
 }
 
+// IsSensitive reports whether the field was declared with the Sensitive option.
+func (k *fieldKey[T]) IsSensitive() bool {
+	return k.sensitive
+}
+
+// IsPublic reports whether the field was declared with the PublicField option.
+func (k *fieldKey[T]) IsPublic() bool {
+	return k.public
+}
+
 func (k *fieldKey[T]) NewValue(value any) (FieldValue, bool) {
 	if tv, ok := value.(T); ok {
 		return &fieldValue[T]{value: tv}, true
@@ -0,0 +1,110 @@
+// Package gen provides the helpers shared by the generators that describe
+// errors serialized by errdef, such as the jsonschema and typescript packages.
+package gen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/shiwano/errdef"
+)
+
+// Redacted is the value of sensitive fields in serialized errors.
+var Redacted = errdef.Redact[any](nil).String()
+
+// Field describes a field of serialized errors of a definition.
+type Field struct {
+	Name      string
+	Type      reflect.Type
+	Sensitive bool
+	Fixed     bool // set by the definition
+	Value     any  // the presented value set by the definition
+}
+
+// Fields returns the fields of errors of the definition: the fields set by
+// the definition first, then the kind fields and the common fields.
+// Fields with the same name as a preceding field are ignored.
+func Fields(def errdef.Definition, kindFields, common []errdef.FieldKey) []Field {
+	var fields []Field
+	seen := make(map[string]bool)
+	add := func(key errdef.FieldKey, value errdef.FieldValue) {
+		if seen[key.String()] {
+			return
+		}
+		seen[key.String()] = true
+
+		f := Field{
+			Name:      key.String(),
+			Type:      reflect.TypeOf(key.ZeroValue().Value()),
+			Sensitive: IsSensitive(key),
+		}
+		if value != nil {
+			f.Fixed = true
+			f.Value = errdef.PresentFieldValue(key, value)
+			if f.Type == nil {
+				f.Type = reflect.TypeOf(value.Value())
+			}
+		}
+		fields = append(fields, f)
+	}
+
+	for key, value := range def.Fields().All() {
+		add(key, value)
+	}
+	for _, key := range kindFields {
+		add(key, nil)
+	}
+	for _, key := range common {
+		add(key, nil)
+	}
+	return fields
+}
+
+// IsSensitive reports whether the key is declared with errdef.Sensitive.
+func IsSensitive(key errdef.FieldKey) bool {
+	k, ok := key.(interface{ IsSensitive() bool })
+	return ok && k.IsSensitive()
+}
+
+// KindDescription describes the kind and the HTTP status of the definition.
+func KindDescription(def errdef.Definition) string {
+	desc := fmt.Sprintf("An error of kind %q.", def.Kind())
+	if status, ok := errdef.HTTPStatusFrom(def); ok {
+		desc += fmt.Sprintf(" HTTP status %d.", status)
+	}
+	return desc
+}
+
+// Names returns unique names for the definitions, given by name and
+// suffixed with a number if already used, including by reserved.
+func Names(defs []errdef.Definition, reserved []string, name func(def errdef.Definition) string) map[errdef.Definition]string {
+	names := make(map[errdef.Definition]string, len(defs))
+	used := make(map[string]bool, len(reserved)+len(defs))
+	for _, r := range reserved {
+		used[r] = true
+	}
+	for _, def := range defs {
+		base := name(def)
+		n := base
+		for i := 2; used[n]; i++ {
+			n = fmt.Sprintf("%s%d", base, i)
+		}
+		used[n] = true
+		names[def] = n
+	}
+	return names
+}
+
+// CamelCase converts a kind such as "auth.invalid_token" into "AuthInvalidToken".
+func CamelCase(s string) string {
+	var b strings.Builder
+	for _, w := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		r := []rune(w)
+		b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+	}
+	return b.String()
+}
@@ -0,0 +1,408 @@
+// Package wire maps error data onto the value model shared by the binary
+// encodings (MessagePack and CBOR): nil, booleans, integers, floats, strings,
+// byte strings, timestamps, arrays, and string-keyed maps.
+package wire
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/unmarshaler"
+)
+
+type (
+	// Writer writes the values of the model in a binary encoding.
+	Writer interface {
+		WriteNil()
+		WriteBool(v bool)
+		WriteInt(v int64)
+		WriteUint(v uint64)
+		WriteFloat32(v float32)
+		WriteFloat64(v float64)
+		WriteString(v string)
+		WriteBytes(v []byte)
+		WriteTime(v time.Time)
+		WriteArrayHeader(n int)
+		WriteMapHeader(n int)
+	}
+
+	// Reader reads the values of the model from a binary encoding.
+	// The methods that read a specific type return an error if the next value
+	// is of another type.
+	Reader interface {
+		// ReadValue reads the next value as nil, bool, int64, uint64 (for
+		// integers that do not fit in int64), float64, string, []byte,
+		// time.Time, []any, or map[string]any.
+		ReadValue() (any, error)
+		// ReadNil reports whether the next value is nil, reading it if so.
+		ReadNil() (bool, error)
+		// ReadBreak reports whether the next item ends a map or an array with
+		// an indefinite length, reading it if so.
+		ReadBreak() (bool, error)
+		// ReadMapHeader reads the header of a map and returns its number of
+		// entries, or -1 if the length is indefinite.
+		ReadMapHeader() (int, error)
+		// ReadArrayHeader reads the header of an array and returns its number
+		// of elements, or -1 if the length is indefinite.
+		ReadArrayHeader() (int, error)
+		// ReadStringBytes reads a string. The returned bytes are valid only
+		// until the next read.
+		ReadStringBytes() ([]byte, error)
+		// ReadInt reads an integer that fits in int64.
+		ReadInt() (int64, error)
+	}
+)
+
+// MaxDepth is the maximum nesting depth of values that readers accept.
+const MaxDepth = 1000
+
+var errUnsupportedType = errors.New("unsupported type")
+
+// WriteData writes the error data as a map with the same keys as its JSON
+// encoding: message, kind, type, fields, stack, and causes.
+// Empty entries other than message are omitted.
+func WriteData(w Writer, d *unmarshaler.DecodedData) error {
+	n := 1
+	for _, ok := range []bool{d.Kind != "", d.Type != "", len(d.Fields) > 0, len(d.Stack) > 0, len(d.Causes) > 0} {
+		if ok {
+			n++
+		}
+	}
+	w.WriteMapHeader(n)
+
+	w.WriteString("message")
+	w.WriteString(d.Message)
+	if d.Kind != "" {
+		w.WriteString("kind")
+		w.WriteString(string(d.Kind))
+	}
+	if d.Type != "" {
+		w.WriteString("type")
+		w.WriteString(d.Type)
+	}
+	if len(d.Fields) > 0 {
+		w.WriteString("fields")
+		w.WriteMapHeader(len(d.Fields))
+		for _, name := range sortedKeys(d.Fields) {
+			w.WriteString(name)
+			if err := WriteValue(w, d.Fields[name]); err != nil {
+				return fmt.Errorf("field %q: %w", name, err)
+			}
+		}
+	}
+	if len(d.Stack) > 0 {
+		w.WriteString("stack")
+		w.WriteArrayHeader(len(d.Stack))
+		for _, f := range d.Stack {
+			w.WriteMapHeader(3)
+			w.WriteString("func")
+			w.WriteString(f.Func)
+			w.WriteString("file")
+			w.WriteString(f.File)
+			w.WriteString("line")
+			w.WriteInt(int64(f.Line))
+		}
+	}
+	if len(d.Causes) > 0 {
+		w.WriteString("causes")
+		w.WriteArrayHeader(len(d.Causes))
+		for _, c := range d.Causes {
+			if err := WriteData(w, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteValue writes a field value by its Go type.
+// Values of derived types (e.g., slog.Level) are written by their kinds,
+// time.Duration as nanoseconds, and types without a counterpart in the model
+// (e.g., structs) as their JSON representation.
+func WriteValue(w Writer, v any) error {
+	switch v := v.(type) {
+	case nil:
+		w.WriteNil()
+		return nil
+	case string:
+		w.WriteString(v)
+		return nil
+	case json.RawMessage:
+		return writeJSON(w, v)
+	case []byte:
+		w.WriteBytes(v)
+		return nil
+	case time.Time:
+		w.WriteTime(v)
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		w.WriteBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.WriteInt(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		w.WriteUint(rv.Uint())
+	case reflect.Float32:
+		w.WriteFloat32(float32(rv.Float()))
+	case reflect.Float64:
+		w.WriteFloat64(rv.Float())
+	case reflect.String:
+		w.WriteString(rv.String())
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			w.WriteNil()
+			return nil
+		}
+		return WriteValue(w, rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			w.WriteBytes(rv.Bytes())
+			return nil
+		}
+		w.WriteArrayHeader(rv.Len())
+		for i := range rv.Len() {
+			if err := WriteValue(w, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return writeValueAsJSON(w, v)
+		}
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+		w.WriteMapHeader(len(keys))
+		for _, k := range keys {
+			w.WriteString(k.String())
+			if err := WriteValue(w, rv.MapIndex(k).Interface()); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct: // e.g., errdef.Redacted
+		return writeValueAsJSON(w, v)
+	default:
+		return fmt.Errorf("%w %T", errUnsupportedType, v)
+	}
+	return nil
+}
+
+func writeValueAsJSON(w Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, data)
+}
+
+// writeJSON writes the JSON data as the values of the model.
+// Integers are written as integers rather than floats.
+func writeJSON(w Writer, data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	return writeJSONValue(w, v)
+}
+
+func writeJSONValue(w Writer, v any) error {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			w.WriteInt(i)
+			return nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return err
+		}
+		w.WriteFloat64(f)
+	case []any:
+		w.WriteArrayHeader(len(v))
+		for _, e := range v {
+			if err := writeJSONValue(w, e); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		w.WriteMapHeader(len(v))
+		for _, k := range sortedKeys(v) {
+			w.WriteString(k)
+			if err := writeJSONValue(w, v[k]); err != nil {
+				return err
+			}
+		}
+	default: // nil, bool, or string
+		return WriteValue(w, v)
+	}
+	return nil
+}
+
+// ReadData reads error data written by WriteData.
+// Unknown keys are skipped, and nil is accepted for any entry.
+func ReadData(r Reader) (*unmarshaler.DecodedData, error) {
+	return readData(r, 0)
+}
+
+func readData(r Reader, depth int) (*unmarshaler.DecodedData, error) {
+	if depth > MaxDepth {
+		return nil, fmt.Errorf("nesting depth exceeds %d", MaxDepth)
+	}
+
+	var d unmarshaler.DecodedData
+	err := readMap(r, func(key []byte) error {
+		var err error
+		switch string(key) {
+		case "message":
+			d.Message, err = readString(r)
+		case "kind":
+			var kind string
+			kind, err = readString(r)
+			d.Kind = errdef.Kind(kind)
+		case "type":
+			d.Type, err = readString(r)
+		case "fields":
+			err = readMap(r, func(name []byte) error {
+				v, err := r.ReadValue()
+				if err != nil {
+					return err
+				}
+				if d.Fields == nil {
+					d.Fields = make(map[string]any)
+				}
+				d.Fields[string(name)] = v
+				return nil
+			})
+		case "stack":
+			err = readArray(r, func() error {
+				f, err := readFrame(r)
+				if err != nil {
+					return err
+				}
+				d.Stack = append(d.Stack, f)
+				return nil
+			})
+		case "causes":
+			err = readArray(r, func() error {
+				c, err := readData(r, depth+1)
+				if err != nil {
+					return err
+				}
+				d.Causes = append(d.Causes, c)
+				return nil
+			})
+		default:
+			_, err = r.ReadValue()
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func readFrame(r Reader) (errdef.Frame, error) {
+	var f errdef.Frame
+	err := readMap(r, func(key []byte) error {
+		var err error
+		switch string(key) {
+		case "func":
+			f.Func, err = readString(r)
+		case "file":
+			f.File, err = readString(r)
+		case "line":
+			var line int64
+			if line, err = r.ReadInt(); err == nil {
+				if line < math.MinInt32 || line > math.MaxInt32 {
+					return fmt.Errorf("line %d out of range", line)
+				}
+				f.Line = int(line)
+			}
+		default:
+			_, err = r.ReadValue()
+		}
+		return err
+	})
+	return f, err
+}
+
+// readMap reads a map with string keys, calling fn with each key to read its value.
+// The key is valid only until fn returns.
+func readMap(r Reader, fn func(key []byte) error) error {
+	if ok, err := r.ReadNil(); ok || err != nil {
+		return err
+	}
+	n, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+	for i := 0; n < 0 || i < n; i++ {
+		if n < 0 {
+			if ok, err := r.ReadBreak(); ok || err != nil {
+				return err
+			}
+		}
+		key, err := r.ReadStringBytes()
+		if err != nil {
+			return err
+		}
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readArray(r Reader, fn func() error) error {
+	if ok, err := r.ReadNil(); ok || err != nil {
+		return err
+	}
+	n, err := r.ReadArrayHeader()
+	if err != nil {
+		return err
+	}
+	for i := 0; n < 0 || i < n; i++ {
+		if n < 0 {
+			if ok, err := r.ReadBreak(); ok || err != nil {
+				return err
+			}
+		}
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readString(r Reader) (string, error) {
+	if ok, err := r.ReadNil(); ok || err != nil {
+		return "", err
+	}
+	data, err := r.ReadStringBytes()
+	return string(data), err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
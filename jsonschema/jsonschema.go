@@ -0,0 +1,219 @@
+// Package jsonschema generates JSON Schemas and OpenAPI components that describe
+// errors serialized by errdef.
+//
+// The generated schemas describe the JSON produced by the default
+// Presenter.MarshalErrorJSON: message, kind, fields, stack, and causes.
+// Each Kind becomes its own schema with a constant kind and typed fields, and
+// the root schema is a union of them discriminated by kind.
+//
+// Field types are derived from the Go types of the FieldKeys via reflection.
+// Fields set by the definitions themselves are always known; fields that are
+// attached when errors are created (e.g., with Definition.With) must be given
+// with WithFields or WithKindFields.
+//
+// Definitions with a custom JSONMarshaler are not supported, since their
+// output cannot be inferred.
+package jsonschema
+
+import (
+	"strings"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/internal/gen"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+type (
+	// Schema is a JSON Schema object.
+	Schema map[string]any
+
+	// Generator generates schemas for a set of definitions.
+	Generator struct {
+		defs       []errdef.Definition
+		fields     []errdef.FieldKey
+		kindFields map[errdef.Definition][]errdef.FieldKey
+		title      string
+		id         string
+		names      map[errdef.Definition]string
+	}
+
+	// Option configures a Generator.
+	Option func(*Generator)
+)
+
+// New creates a new Generator for the given definitions.
+// The order of definitions is kept in the generated unions.
+//
+//	g := jsonschema.New(Resolver.Definitions(), jsonschema.WithFields(UserID.Key()))
+func New(defs []errdef.Definition, opts ...Option) *Generator {
+	g := &Generator{
+		defs:       defs,
+		kindFields: make(map[errdef.Definition][]errdef.FieldKey),
+		title:      "Error",
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.names = gen.Names(defs, []string{g.title, g.title + "Frame", g.title + "Cause"}, func(def errdef.Definition) string {
+		return g.title + gen.CamelCase(string(def.Kind()))
+	})
+	return g
+}
+
+// WithFields returns an Option that declares fields that may be attached to
+// errors of any kind.
+func WithFields(keys ...errdef.FieldKey) Option {
+	return func(g *Generator) {
+		g.fields = append(g.fields, keys...)
+	}
+}
+
+// WithKindFields returns an Option that declares fields that may be attached to
+// errors of the given definition.
+func WithKindFields(def errdef.Definition, keys ...errdef.FieldKey) Option {
+	return func(g *Generator) {
+		g.kindFields[def] = append(g.kindFields[def], keys...)
+	}
+}
+
+// WithTitle returns an Option that sets the title of the root schema (default "Error").
+// The title is also used as the prefix of the names of the other schemas.
+func WithTitle(title string) Option {
+	return func(g *Generator) {
+		g.title = title
+	}
+}
+
+// WithID returns an Option that sets the $id of the JSON Schema.
+func WithID(id string) Option {
+	return func(g *Generator) {
+		g.id = id
+	}
+}
+
+// JSONSchema returns a JSON Schema document that describes the serialized errors.
+// The schemas of the kinds are placed in $defs.
+func (g *Generator) JSONSchema() Schema {
+	const ref = "#/$defs/"
+
+	defs := g.commonSchemas(ref)
+	for _, def := range g.defs {
+		defs[g.names[def]] = g.kindSchema(def, ref)
+	}
+
+	s := Schema{
+		"$schema": Draft,
+		"title":   g.title,
+		"oneOf":   g.kindRefs(ref),
+		"$defs":   defs,
+	}
+	if g.id != "" {
+		s["$id"] = g.id
+	}
+	return s
+}
+
+// SchemaName returns the name of the schema generated for the definition,
+// which is the title followed by the kind in CamelCase (e.g., "ErrorNotFound").
+func (g *Generator) SchemaName(def errdef.Definition) string {
+	return g.names[def]
+}
+
+func (g *Generator) kindRefs(ref string) []Schema {
+	refs := make([]Schema, len(g.defs))
+	for i, def := range g.defs {
+		refs[i] = Schema{"$ref": ref + g.names[def]}
+	}
+	return refs
+}
+
+func (g *Generator) commonSchemas(ref string) map[string]Schema {
+	return map[string]Schema{
+		g.title + "Frame": {
+			"type": "object",
+			"properties": Schema{
+				"func": Schema{"type": "string"},
+				"file": Schema{"type": "string"},
+				"line": Schema{"type": "integer"},
+			},
+			"required": []string{"func", "file", "line"},
+		},
+		g.title + "Cause": {
+			"description": "A cause of an error: an errdef error, or another error with its Go type.",
+			"type":        "object",
+			"properties": Schema{
+				"message": Schema{"type": "string"},
+				"kind":    Schema{"type": "string"},
+				"type":    Schema{"type": "string"},
+				"fields":  Schema{"type": "object"},
+				"stack":   Schema{"type": "array", "items": Schema{"$ref": ref + g.title + "Frame"}},
+				"causes":  Schema{"type": "array", "items": Schema{"$ref": ref + g.title + "Cause"}},
+			},
+			"required": []string{"message"},
+		},
+	}
+}
+
+func (g *Generator) kindSchema(def errdef.Definition, ref string) Schema {
+	fields := gen.Fields(def, g.kindFields[def], g.fields)
+
+	props := Schema{}
+	var required []string
+	for _, f := range fields {
+		s := typeSchema(f.Type)
+		if f.Sensitive {
+			s = Schema{"anyOf": []Schema{s, {"const": gen.Redacted}}}
+		}
+		if f.Fixed {
+			s["default"] = jsonValue(f.Value)
+			required = append(required, f.Name)
+		}
+		props[f.Name] = s
+	}
+	fieldsSchema := Schema{"type": "object", "properties": props}
+	if len(required) > 0 {
+		fieldsSchema["required"] = required
+	}
+
+	s := Schema{
+		"type":        "object",
+		"description": gen.KindDescription(def),
+		"properties": Schema{
+			"message": Schema{"type": "string"},
+			"kind":    Schema{"type": "string", "const": string(def.Kind())},
+			"fields":  fieldsSchema,
+			"stack":   Schema{"type": "array", "items": Schema{"$ref": ref + g.title + "Frame"}},
+			"causes":  Schema{"type": "array", "items": Schema{"$ref": ref + g.title + "Cause"}},
+		},
+		"required": []string{"message", "kind"},
+	}
+	if len(required) > 0 {
+		s["required"] = []string{"message", "kind", "fields"}
+	}
+	return s
+}
+
+// example returns an example of the serialized error of the definition.
+func (g *Generator) example(def errdef.Definition) map[string]any {
+	e := map[string]any{
+		"message": strings.ReplaceAll(string(def.Kind()), "_", " "),
+		"kind":    string(def.Kind()),
+	}
+	fields := make(map[string]any)
+	for _, f := range gen.Fields(def, g.kindFields[def], g.fields) {
+		switch {
+		case f.Sensitive:
+			fields[f.Name] = gen.Redacted
+		case f.Fixed:
+			fields[f.Name] = jsonValue(f.Value)
+		default:
+			fields[f.Name] = sampleValue(f.Type)
+		}
+	}
+	if len(fields) > 0 {
+		e["fields"] = fields
+	}
+	return e
+}
@@ -0,0 +1,175 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/jsonschema"
+)
+
+func TestGenerator_JSONSchema(t *testing.T) {
+	userID, _ := errdef.DefineField[string]("user_id")
+	password, _ := errdef.DefineField[string]("password", errdef.Sensitive())
+	notFound := errdef.Define("not_found", errdef.HTTPStatus(404), errdef.LogLevel(slog.LevelWarn))
+	timeout := errdef.Define("upstream.timeout", errdef.RetryAfter(time.Second))
+
+	g := jsonschema.New(
+		[]errdef.Definition{notFound, timeout},
+		jsonschema.WithFields(password.Key()),
+		jsonschema.WithKindFields(notFound, userID.Key()),
+		jsonschema.WithID("https://example.com/error.json"),
+	)
+	s := g.JSONSchema()
+
+	t.Run("root", func(t *testing.T) {
+		if s["$schema"] != jsonschema.Draft || s["$id"] != "https://example.com/error.json" || s["title"] != "Error" {
+			t.Errorf("unexpected root: %v", s)
+		}
+		want := []jsonschema.Schema{
+			{"$ref": "#/$defs/ErrorNotFound"},
+			{"$ref": "#/$defs/ErrorUpstreamTimeout"},
+		}
+		if got := s["oneOf"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("want oneOf %v, got %v", want, got)
+		}
+		if g.SchemaName(timeout) != "ErrorUpstreamTimeout" {
+			t.Errorf("want schema name %q, got %q", "ErrorUpstreamTimeout", g.SchemaName(timeout))
+		}
+	})
+
+	t.Run("kind", func(t *testing.T) {
+		kind := defOf(t, s, "ErrorNotFound")
+		props := kind["properties"].(jsonschema.Schema)
+		if want := (jsonschema.Schema{"type": "string", "const": "not_found"}); !reflect.DeepEqual(props["kind"], want) {
+			t.Errorf("want kind %v, got %v", want, props["kind"])
+		}
+		if want := []string{"message", "kind", "fields"}; !reflect.DeepEqual(kind["required"], want) {
+			t.Errorf("want required %v, got %v", want, kind["required"])
+		}
+
+		fields := props["fields"].(jsonschema.Schema)
+		want := jsonschema.Schema{
+			"type": "object",
+			"properties": jsonschema.Schema{
+				"http_status": jsonschema.Schema{"type": "integer", "default": 404},
+				"log_level":   jsonschema.Schema{"type": "string", "default": "WARN"},
+				"user_id":     jsonschema.Schema{"type": "string"},
+				"password": jsonschema.Schema{"anyOf": []jsonschema.Schema{
+					{"type": "string"},
+					{"const": "[REDACTED]"},
+				}},
+			},
+			"required": []string{"http_status", "log_level"},
+		}
+		if got := jsonRoundTrip(t, fields); !reflect.DeepEqual(got, jsonRoundTrip(t, want)) {
+			t.Errorf("want fields %v, got %v", want, fields)
+		}
+	})
+
+	t.Run("serialized error", func(t *testing.T) {
+		err := notFound.With(t.Context(), userID("u1"), password("secret")).Wrap(timeout.New("timed out"))
+		data, _ := json.Marshal(err)
+		var got map[string]any
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+
+		kind := defOf(t, s, "ErrorNotFound")
+		props := kind["properties"].(jsonschema.Schema)
+		for name := range got {
+			if _, ok := props[name]; !ok {
+				t.Errorf("want property %q in schema", name)
+			}
+		}
+		fieldProps := props["fields"].(jsonschema.Schema)["properties"].(jsonschema.Schema)
+		for name := range got["fields"].(map[string]any) {
+			if _, ok := fieldProps[name]; !ok {
+				t.Errorf("want field %q in schema", name)
+			}
+		}
+	})
+}
+
+func TestGenerator_FieldTypes(t *testing.T) {
+	type (
+		inner struct {
+			Name string `json:"name"`
+		}
+		payload struct {
+			ID       int               `json:"id"`
+			Tags     []string          `json:"tags,omitempty"`
+			Inner    *inner            `json:"inner"`
+			Labels   map[string]string `json:"labels"`
+			Data     []byte            `json:"data"`
+			At       time.Time         `json:"at"`
+			Ignored  string            `json:"-"`
+			internal string
+		}
+	)
+	ratio, _ := errdef.DefineField[float64]("ratio")
+	level, _ := errdef.DefineField[slog.Level]("level")
+	secret, _ := errdef.DefineField[errdef.Redacted[string]]("secret")
+	body, _ := errdef.DefineField[payload]("body")
+	anything, _ := errdef.DefineField[any]("anything")
+
+	def := errdef.Define("typed")
+	s := jsonschema.New([]errdef.Definition{def},
+		jsonschema.WithFields(ratio.Key(), level.Key(), secret.Key(), body.Key(), anything.Key()),
+	).JSONSchema()
+	fields := defOf(t, s, "ErrorTyped")["properties"].(jsonschema.Schema)["fields"].(jsonschema.Schema)
+	props := fields["properties"].(jsonschema.Schema)
+
+	tests := map[string]jsonschema.Schema{
+		"ratio":    {"type": "number"},
+		"level":    {"type": "string"},
+		"secret":   {"type": "string"},
+		"anything": {},
+		"body": {
+			"type": "object",
+			"properties": jsonschema.Schema{
+				"id":   jsonschema.Schema{"type": "integer"},
+				"tags": jsonschema.Schema{"type": "array", "items": jsonschema.Schema{"type": "string"}},
+				"inner": jsonschema.Schema{"anyOf": []jsonschema.Schema{
+					{"type": "object", "properties": jsonschema.Schema{"name": jsonschema.Schema{"type": "string"}}, "required": []string{"name"}},
+					{"type": "null"},
+				}},
+				"labels": jsonschema.Schema{"type": "object", "additionalProperties": jsonschema.Schema{"type": "string"}},
+				"data":   jsonschema.Schema{"type": "string", "contentEncoding": "base64"},
+				"at":     jsonschema.Schema{"type": "string", "format": "date-time"},
+			},
+			"required": []string{"id", "inner", "labels", "data", "at"},
+		},
+	}
+	for name, want := range tests {
+		if got := props[name]; !reflect.DeepEqual(jsonRoundTrip(t, got), jsonRoundTrip(t, want)) {
+			t.Errorf("%s: want %v, got %v", name, want, got)
+		}
+	}
+}
+
+func defOf(t *testing.T, s jsonschema.Schema, name string) jsonschema.Schema {
+	t.Helper()
+	defs := s["$defs"].(map[string]jsonschema.Schema)
+	d, ok := defs[name]
+	if !ok {
+		t.Fatalf("want $defs/%s", name)
+	}
+	return d
+}
+
+func jsonRoundTrip(t *testing.T, v any) any {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
@@ -0,0 +1,97 @@
+package jsonschema
+
+import (
+	"fmt"
+
+	"github.com/shiwano/errdef"
+)
+
+type (
+	// Components is the components object of an OpenAPI 3.1 document.
+	// It can be marshaled to JSON or YAML and merged into an API spec.
+	Components struct {
+		Schemas   map[string]Schema   `json:"schemas"`
+		Examples  map[string]Example  `json:"examples,omitempty"`
+		Responses map[string]Response `json:"responses,omitempty"`
+	}
+
+	// Example is an OpenAPI example object.
+	Example struct {
+		Summary string `json:"summary,omitempty"`
+		Value   any    `json:"value"`
+	}
+
+	// Response is an OpenAPI response object.
+	Response struct {
+		Description string               `json:"description"`
+		Content     map[string]MediaType `json:"content,omitempty"`
+	}
+
+	// MediaType is an OpenAPI media type object.
+	MediaType struct {
+		Schema   Schema            `json:"schema,omitempty"`
+		Examples map[string]Schema `json:"examples,omitempty"`
+	}
+)
+
+// OpenAPIComponents returns OpenAPI 3.1 components that describe the serialized errors.
+//
+// The components contain a schema per kind, a root schema (named after the
+// title) that is a union of them with a discriminator on kind, an example per
+// kind, and a response per kind that refers to them:
+//
+//	responses:
+//	  "404":
+//	    $ref: "#/components/responses/ErrorNotFound"
+func (g *Generator) OpenAPIComponents() *Components {
+	const (
+		schemaRef  = "#/components/schemas/"
+		exampleRef = "#/components/examples/"
+	)
+
+	c := &Components{
+		Schemas:   g.commonSchemas(schemaRef),
+		Examples:  make(map[string]Example, len(g.defs)),
+		Responses: make(map[string]Response, len(g.defs)),
+	}
+
+	mapping := make(map[string]string, len(g.defs))
+	for _, def := range g.defs {
+		name := g.names[def]
+		mapping[string(def.Kind())] = schemaRef + name
+
+		c.Schemas[name] = g.kindSchema(def, schemaRef)
+		c.Examples[name] = Example{
+			Summary: string(def.Kind()),
+			Value:   g.example(def),
+		}
+		c.Responses[name] = Response{
+			Description: responseDescription(def),
+			Content: map[string]MediaType{
+				"application/json": {
+					Schema: Schema{"$ref": schemaRef + name},
+					Examples: map[string]Schema{
+						string(def.Kind()): {"$ref": exampleRef + name},
+					},
+				},
+			},
+		}
+	}
+
+	c.Schemas[g.title] = Schema{
+		"title": g.title,
+		"oneOf": g.kindRefs(schemaRef),
+		"discriminator": Schema{
+			"propertyName": "kind",
+			"mapping":      mapping,
+		},
+	}
+	return c
+}
+
+func responseDescription(def errdef.Definition) string {
+	if status, ok := errdef.HTTPStatusFrom(def); ok {
+		return fmt.Sprintf("%s (HTTP %d)", def.Kind(), status)
+	}
+	return string(def.Kind())
+}
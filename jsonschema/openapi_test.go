@@ -0,0 +1,81 @@
+package jsonschema_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/jsonschema"
+)
+
+func TestGenerator_OpenAPIComponents(t *testing.T) {
+	userID, _ := errdef.DefineField[string]("user_id")
+	notFound := errdef.Define("not_found", errdef.HTTPStatus(404), errdef.Public())
+	conflict := errdef.Define("conflict")
+
+	c := jsonschema.New(
+		[]errdef.Definition{notFound, conflict},
+		jsonschema.WithFields(userID.Key()),
+		jsonschema.WithTitle("APIError"),
+	).OpenAPIComponents()
+
+	t.Run("schemas", func(t *testing.T) {
+		for _, name := range []string{"APIError", "APIErrorNotFound", "APIErrorConflict", "APIErrorFrame", "APIErrorCause"} {
+			if _, ok := c.Schemas[name]; !ok {
+				t.Errorf("want schema %q", name)
+			}
+		}
+		want := jsonschema.Schema{
+			"propertyName": "kind",
+			"mapping": map[string]string{
+				"not_found": "#/components/schemas/APIErrorNotFound",
+				"conflict":  "#/components/schemas/APIErrorConflict",
+			},
+		}
+		if got := c.Schemas["APIError"]["discriminator"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("want discriminator %v, got %v", want, got)
+		}
+		stack := c.Schemas["APIErrorNotFound"]["properties"].(jsonschema.Schema)["stack"]
+		if want := (jsonschema.Schema{"type": "array", "items": jsonschema.Schema{"$ref": "#/components/schemas/APIErrorFrame"}}); !reflect.DeepEqual(stack, want) {
+			t.Errorf("want stack %v, got %v", want, stack)
+		}
+	})
+
+	t.Run("examples", func(t *testing.T) {
+		want := jsonschema.Example{
+			Summary: "not_found",
+			Value: map[string]any{
+				"message": "not found",
+				"kind":    "not_found",
+				"fields": map[string]any{
+					"http_status": float64(404),
+					"public":      true,
+					"user_id":     "",
+				},
+			},
+		}
+		if got := c.Examples["APIErrorNotFound"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("want example %v, got %v", want, got)
+		}
+	})
+
+	t.Run("responses", func(t *testing.T) {
+		want := jsonschema.Response{
+			Description: "not_found (HTTP 404)",
+			Content: map[string]jsonschema.MediaType{
+				"application/json": {
+					Schema: jsonschema.Schema{"$ref": "#/components/schemas/APIErrorNotFound"},
+					Examples: map[string]jsonschema.Schema{
+						"not_found": {"$ref": "#/components/examples/APIErrorNotFound"},
+					},
+				},
+			},
+		}
+		if got := c.Responses["APIErrorNotFound"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("want response %v, got %v", want, got)
+		}
+		if got := c.Responses["APIErrorConflict"].Description; got != "conflict" {
+			t.Errorf("want description %q, got %q", "conflict", got)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	durationType      = reflect.TypeFor[time.Duration]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// typeSchema returns the schema of the JSON encoding of values of type t.
+// A nil type (e.g., the type of an interface field) has an empty schema.
+func typeSchema(t reflect.Type) Schema {
+	return (&typeVisitor{visiting: make(map[reflect.Type]bool)}).schema(t)
+}
+
+type typeVisitor struct {
+	visiting map[reflect.Type]bool
+}
+
+func (v *typeVisitor) schema(t reflect.Type) Schema {
+	if t == nil {
+		return Schema{}
+	}
+
+	switch {
+	case t == timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	case t == durationType:
+		return Schema{"type": "integer", "description": "Duration in nanoseconds."}
+	case implements(t, jsonMarshalerType):
+		if implements(t, textMarshalerType) {
+			return Schema{"type": "string"} // e.g., slog.Level, Redacted
+		}
+		return Schema{} // the encoding is unknown
+	case implements(t, textMarshalerType):
+		return Schema{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Pointer:
+		return Schema{"anyOf": []Schema{v.schema(t.Elem()), {"type": "null"}}}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && !implements(t.Elem(), jsonMarshalerType) {
+			return Schema{"type": "string", "contentEncoding": "base64"}
+		}
+		return Schema{"type": "array", "items": v.schema(t.Elem())}
+	case reflect.Array:
+		return Schema{"type": "array", "items": v.schema(t.Elem()), "minItems": t.Len(), "maxItems": t.Len()}
+	case reflect.Map:
+		switch t.Key().Kind() {
+		case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return Schema{"type": "object", "additionalProperties": v.schema(t.Elem())}
+		}
+		return Schema{"type": "object"}
+	case reflect.Struct:
+		return v.structSchema(t)
+	}
+	return Schema{}
+}
+
+func (v *typeVisitor) structSchema(t reflect.Type) Schema {
+	if v.visiting[t] {
+		return Schema{"type": "object"} // recursive type
+	}
+	v.visiting[t] = true
+	defer delete(v.visiting, t)
+
+	props := Schema{}
+	var required []string
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			continue // promoted fields of embedded structs are visited on their own
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = v.schema(f.Type)
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") {
+			required = append(required, name)
+		}
+	}
+
+	s := Schema{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// sampleValue returns a sample JSON value of type t, used in examples.
+func sampleValue(t reflect.Type) any {
+	if t == nil {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return ""
+		}
+		return []any{}
+	case reflect.Map:
+		return map[string]any{}
+	}
+	return jsonValue(reflect.Zero(t).Interface())
+}
+
+// jsonValue returns the JSON representation of v as a generic value.
+func jsonValue(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil
+	}
+	return value
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
@@ -0,0 +1,30 @@
+package marshaler
+
+import (
+	"encoding/json"
+
+	"github.com/shiwano/errdef/unmarshaler"
+)
+
+type (
+	// Encoder is a function that encodes EncodedData into data of type T.
+	//
+	// The type parameter T specifies the output data type, enabling type-safe
+	// serialization into various formats beyond []byte. Common examples include:
+	//   - []byte for JSON, XML, or other text-based formats
+	//   - Protocol Buffers messages (e.g., *ErrorProto)
+	//   - Custom structured data types
+	Encoder[T any] func(data *EncodedData) (T, error)
+
+	// EncodedData represents the error information extracted from an error
+	// instance. It is the same structure as unmarshaler.DecodedData, so that
+	// any data produced by a Marshaler can be given back to an unmarshaler.Decoder.
+	//
+	// Field values are kept as their Go values (e.g., int, time.Duration) after
+	// redaction, so that encoders can preserve their types.
+	EncodedData = unmarshaler.DecodedData
+)
+
+func encodedDataToJSON(data *EncodedData) ([]byte, error) {
+	return json.Marshal(data)
+}
@@ -0,0 +1,151 @@
+// Package marshaler serializes errdef errors into various formats.
+//
+// It is the counterpart of the unmarshaler package: a Marshaler converts an
+// errdef.Error into EncodedData, the same structure that unmarshaler decoders
+// produce, and then encodes it into T with an Encoder. Any format that has a
+// Decoder can therefore be given an Encoder to round-trip errors:
+//
+//	m := marshaler.NewJSON(marshaler.WithoutStack())
+//	data, err := m.Marshal(err)
+//	// ...
+//	u := unmarshaler.NewJSON(resolver)
+//	restored, err := u.Unmarshal(data)
+//
+// By default the output matches the JSON produced by the errors themselves:
+// messages are scrubbed and sensitive fields are redacted. Definitions with a
+// custom JSONMarshaler are encoded in the standard shape.
+package marshaler
+
+import (
+	"fmt"
+
+	"github.com/shiwano/errdef"
+)
+
+type (
+	// Marshaler marshals errors into serialized data of type T.
+	//
+	// The type parameter T specifies the output data type that the encoder produces.
+	// This allows type-safe serialization into various formats beyond []byte,
+	// such as Protocol Buffers messages or other structured data types.
+	//
+	// Common usage:
+	//   - For JSON: Use NewJSON which returns *Marshaler[[]byte]
+	//   - For custom formats: Use New with a custom Encoder[T]
+	Marshaler[T any] struct {
+		*marshaler
+		encoder Encoder[T]
+	}
+
+	// Option is a function type for customizing Marshaler configuration.
+	Option func(*marshaler)
+
+	marshaler struct {
+		stackDepth     int
+		maxCauseDepth  int
+		redactedFields map[string]struct{}
+		presentField   func(key errdef.FieldKey, value errdef.FieldValue) any
+	}
+)
+
+var redactedStr = errdef.Redact[any](nil).String()
+
+// New creates a new Marshaler with the given encoder and options.
+//
+// The type parameter T is inferred from the encoder's output type, ensuring
+// type-safe marshaling. The encoder function converts an EncodedData
+// structure into output data of type T.
+//
+// For JSON serialization, consider using NewJSON instead of New directly.
+func New[T any](encoder Encoder[T], opts ...Option) *Marshaler[T] {
+	m := &Marshaler[T]{
+		marshaler: &marshaler{
+			stackDepth:     -1,
+			maxCauseDepth:  -1,
+			redactedFields: make(map[string]struct{}),
+			presentField:   errdef.PresentFieldValue,
+		},
+		encoder: encoder,
+	}
+	for _, opt := range opts {
+		opt(m.marshaler)
+	}
+	return m
+}
+
+// NewJSON creates a new Marshaler with a JSON encoder.
+// The output can be unmarshaled with unmarshaler.NewJSON.
+func NewJSON(opts ...Option) *Marshaler[[]byte] {
+	return New(encodedDataToJSON, opts...)
+}
+
+// Marshal serializes the given error into data of type T.
+func (m *Marshaler[T]) Marshal(err errdef.Error) (T, error) {
+	return m.encoder(m.Encode(err))
+}
+
+// Encode converts the given error into EncodedData without encoding it.
+func (m *Marshaler[T]) Encode(err errdef.Error) *EncodedData {
+	scrub := errdef.GlobalMessageScrubber()
+	data := m.encodeError(err, errdef.ChainScrubbers(scrub, errdef.CauseMessageScrubberOf(err)))
+	data.Causes = m.encodeNodes(err.UnwrapTree(), 1, errdef.ChainScrubbers(scrub, errdef.MessageScrubberOf(err)))
+	return data
+}
+
+func (m *marshaler) encodeError(err errdef.Error, scrub errdef.Scrubber) *EncodedData {
+	data := &EncodedData{
+		Message: scrubMessage(scrub, err.Error()),
+		Kind:    err.Kind(),
+	}
+
+	if err.Fields().Len() > 0 {
+		data.Fields = make(map[string]any, err.Fields().Len())
+		for key, value := range err.Fields().All() {
+			if _, ok := m.redactedFields[key.String()]; ok {
+				data.Fields[key.String()] = redactedStr
+				continue
+			}
+			data.Fields[key.String()] = m.presentField(key, value)
+		}
+	}
+
+	if m.stackDepth != 0 && err.Stack().Len() > 0 {
+		frames := err.Stack().Frames()
+		if m.stackDepth > 0 && len(frames) > m.stackDepth {
+			frames = frames[:m.stackDepth]
+		}
+		data.Stack = frames
+	}
+	return data
+}
+
+func (m *marshaler) encodeNodes(nodes errdef.Nodes, depth int, scrub errdef.Scrubber) []*EncodedData {
+	if len(nodes) == 0 || m.maxCauseDepth >= 0 && depth > m.maxCauseDepth {
+		return nil
+	}
+
+	result := make([]*EncodedData, len(nodes))
+	for i, n := range nodes {
+		nodeScrub := errdef.ChainScrubbers(scrub, errdef.CauseMessageScrubberOf(n.Error))
+
+		var data *EncodedData
+		switch err := n.Error.(type) {
+		case errdef.Error:
+			data = m.encodeError(err, nodeScrub)
+		case interface{ TypeName() string }:
+			data = &EncodedData{Message: scrubMessage(nodeScrub, n.Error.Error()), Type: err.TypeName()}
+		default:
+			data = &EncodedData{Message: scrubMessage(nodeScrub, n.Error.Error()), Type: fmt.Sprintf("%T", n.Error)}
+		}
+		data.Causes = m.encodeNodes(n.Causes, depth+1, errdef.ChainScrubbers(scrub, errdef.MessageScrubberOf(n.Error)))
+		result[i] = data
+	}
+	return result
+}
+
+func scrubMessage(scrub errdef.Scrubber, msg string) string {
+	if scrub == nil {
+		return msg
+	}
+	return scrub(msg)
+}
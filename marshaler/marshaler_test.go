@@ -0,0 +1,265 @@
+package marshaler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/marshaler"
+	"github.com/shiwano/errdef/resolver"
+	"github.com/shiwano/errdef/unmarshaler"
+)
+
+var (
+	errNotFound = errdef.Define("not_found", errdef.HTTPStatus(404))
+	errInternal = errdef.Define("internal")
+
+	userID, userIDFrom = errdef.DefineField[string]("user_id")
+	count, countFrom   = errdef.DefineField[int]("count")
+	password, _        = errdef.DefineField[string]("password", errdef.Sensitive())
+)
+
+func newTestError() errdef.Error {
+	cause := errInternal.Wrap(errors.New("connection refused"))
+	return errNotFound.WithOptions(userID("u1"), count(3)).Wrapf(cause, "user not found").(errdef.Error)
+}
+
+func TestMarshaler_RoundTrip(t *testing.T) {
+	r := resolver.New(errNotFound, errInternal)
+
+	formats := []struct {
+		name      string
+		roundTrip func(t *testing.T, err errdef.Error) unmarshaler.UnmarshaledError
+	}{
+		{
+			name: "json",
+			roundTrip: func(t *testing.T, err errdef.Error) unmarshaler.UnmarshaledError {
+				data, mErr := marshaler.NewJSON().Marshal(err)
+				if mErr != nil {
+					t.Fatalf("failed to marshal: %v", mErr)
+				}
+				restored, uErr := unmarshaler.NewJSON(r, unmarshaler.WithCustomFields(userID.Key(), count.Key())).Unmarshal(data)
+				if uErr != nil {
+					t.Fatalf("failed to unmarshal: %v", uErr)
+				}
+				return restored
+			},
+		},
+		{
+			name: "custom",
+			roundTrip: func(t *testing.T, err errdef.Error) unmarshaler.UnmarshaledError {
+				encode := func(data *marshaler.EncodedData) (*marshaler.EncodedData, error) { return data, nil }
+				decode := func(data *marshaler.EncodedData) (*unmarshaler.DecodedData, error) { return data, nil }
+
+				data, mErr := marshaler.New(encode).Marshal(err)
+				if mErr != nil {
+					t.Fatalf("failed to marshal: %v", mErr)
+				}
+				restored, uErr := unmarshaler.New(r, decode, unmarshaler.WithCustomFields(userID.Key(), count.Key())).Unmarshal(data)
+				if uErr != nil {
+					t.Fatalf("failed to unmarshal: %v", uErr)
+				}
+				return restored
+			},
+		},
+	}
+
+	for _, f := range formats {
+		t.Run(f.name, func(t *testing.T) {
+			orig := newTestError()
+			restored := f.roundTrip(t, orig)
+
+			if restored.Error() != orig.Error() {
+				t.Errorf("want message %q, got %q", orig.Error(), restored.Error())
+			}
+			if restored.Kind() != "not_found" {
+				t.Errorf("want kind %q, got %q", "not_found", restored.Kind())
+			}
+			if got, ok := userIDFrom(restored); !ok || got != "u1" {
+				t.Errorf("want user_id %q, got %q", "u1", got)
+			}
+			if got, ok := countFrom(restored); !ok || got != 3 {
+				t.Errorf("want count %d, got %d", 3, got)
+			}
+			if got, ok := errdef.HTTPStatusFrom(restored); !ok || got != 404 {
+				t.Errorf("want http_status %d, got %d", 404, got)
+			}
+			if !reflect.DeepEqual(restored.Stack().Frames(), orig.Stack().Frames()) {
+				t.Errorf("want stack %v, got %v", orig.Stack().Frames(), restored.Stack().Frames())
+			}
+
+			causes := restored.UnwrapTree()
+			if len(causes) != 1 {
+				t.Fatalf("want 1 cause, got %d", len(causes))
+			}
+			if cause, ok := causes[0].Error.(errdef.Error); !ok || cause.Kind() != "internal" {
+				t.Errorf("want cause of kind internal, got %v", causes[0].Error)
+			}
+			if len(causes[0].Causes) != 1 {
+				t.Fatalf("want 1 nested cause, got %d", len(causes[0].Causes))
+			}
+			var unknown *unmarshaler.UnknownCauseError
+			if !errors.As(causes[0].Causes[0].Error, &unknown) {
+				t.Fatalf("want UnknownCauseError, got %T", causes[0].Causes[0].Error)
+			}
+			if unknown.TypeName() != "*errors.errorString" || unknown.Error() != "connection refused" {
+				t.Errorf("want *errors.errorString: connection refused, got %s: %s", unknown.TypeName(), unknown.Error())
+			}
+
+			again := f.roundTrip(t, restored)
+			m := marshaler.NewJSON()
+			want, _ := m.Marshal(restored)
+			got, _ := m.Marshal(again)
+			if string(got) != string(want) {
+				t.Errorf("want stable round trip\nwant: %s\ngot:  %s", want, got)
+			}
+		})
+	}
+}
+
+func TestMarshaler_MatchesErrorJSON(t *testing.T) {
+	err := newTestError()
+
+	want, jErr := json.Marshal(err)
+	if jErr != nil {
+		t.Fatalf("failed to marshal: %v", jErr)
+	}
+	got, mErr := marshaler.NewJSON().Marshal(err)
+	if mErr != nil {
+		t.Fatalf("failed to marshal: %v", mErr)
+	}
+
+	var wantValue, gotValue any
+	_ = json.Unmarshal(want, &wantValue)
+	_ = json.Unmarshal(got, &gotValue)
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestMarshaler_Encode(t *testing.T) {
+	t.Run("keeps field types", func(t *testing.T) {
+		data := marshaler.NewJSON().Encode(newTestError())
+		if v, ok := data.Fields["count"].(int); !ok || v != 3 {
+			t.Errorf("want count int 3, got %T %v", data.Fields["count"], data.Fields["count"])
+		}
+	})
+
+	t.Run("redacts sensitive fields", func(t *testing.T) {
+		err := errInternal.WithOptions(password("secret")).New("failed").(errdef.Error)
+		data := marshaler.NewJSON().Encode(err)
+		if data.Fields["password"] != "[REDACTED]" {
+			t.Errorf("want redacted password, got %v", data.Fields["password"])
+		}
+	})
+
+	t.Run("scrubs messages", func(t *testing.T) {
+		def := errdef.Define("scrubbed", errdef.MessageScrubber(errdef.ScrubEmails()))
+		err := def.Wrap(errInternal.Wrap(errors.New("alice@example.com"))).(errdef.Error)
+
+		data := marshaler.NewJSON().Encode(err)
+		if data.Message != "[REDACTED]" {
+			t.Errorf("want scrubbed message, got %q", data.Message)
+		}
+		if got := data.Causes[0].Causes[0].Message; got != "[REDACTED]" {
+			t.Errorf("want scrubbed cause message, got %q", got)
+		}
+	})
+
+	t.Run("scrubs messages with scrubbers of causes", func(t *testing.T) {
+		def := errdef.Define("scrubbed", errdef.MessageScrubber(errdef.ScrubEmails()))
+		err := errInternal.Wrapf(def.Wrap(errors.New("duplicate key a@b.com")), "create user").(errdef.Error)
+
+		data := marshaler.NewJSON().Encode(err)
+		if want := "create user: duplicate key [REDACTED]"; data.Message != want {
+			t.Errorf("want %q, got %q", want, data.Message)
+		}
+		if got := data.Causes[0].Message; got != "duplicate key [REDACTED]" {
+			t.Errorf("want scrubbed cause message, got %q", got)
+		}
+		if got := data.Causes[0].Causes[0].Message; got != "duplicate key [REDACTED]" {
+			t.Errorf("want scrubbed nested cause message, got %q", got)
+		}
+	})
+}
+
+func TestWithoutStack(t *testing.T) {
+	data := marshaler.NewJSON(marshaler.WithoutStack()).Encode(newTestError())
+	if data.Stack != nil {
+		t.Errorf("want no stack, got %v", data.Stack)
+	}
+	if data.Causes[0].Stack != nil {
+		t.Errorf("want no cause stack, got %v", data.Causes[0].Stack)
+	}
+}
+
+func TestWithStackDepth(t *testing.T) {
+	err := newTestError()
+	data := marshaler.NewJSON(marshaler.WithStackDepth(1)).Encode(err)
+	if len(data.Stack) != 1 {
+		t.Fatalf("want 1 frame, got %d", len(data.Stack))
+	}
+	if data.Stack[0] != err.Stack().Frames()[0] {
+		t.Errorf("want head frame %v, got %v", err.Stack().Frames()[0], data.Stack[0])
+	}
+}
+
+func TestWithMaxCauseDepth(t *testing.T) {
+	tests := []struct {
+		depth int
+		want  int
+	}{
+		{depth: -1, want: 2},
+		{depth: 0, want: 0},
+		{depth: 1, want: 1},
+		{depth: 2, want: 2},
+	}
+	for _, tt := range tests {
+		data := marshaler.NewJSON(marshaler.WithMaxCauseDepth(tt.depth)).Encode(newTestError())
+		got := 0
+		for causes := data.Causes; len(causes) > 0; causes = causes[0].Causes {
+			got++
+		}
+		if got != tt.want {
+			t.Errorf("depth %d: want %d levels of causes, got %d", tt.depth, tt.want, got)
+		}
+	}
+}
+
+func TestWithRedactedFields(t *testing.T) {
+	m := marshaler.NewJSON(marshaler.WithRedactedFields(userID.Key()))
+	data, err := m.Marshal(newTestError())
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if strings.Contains(string(data), "u1") {
+		t.Errorf("want user_id to be redacted, got %s", data)
+	}
+
+	r := resolver.New(errNotFound, errInternal)
+	restored, err := unmarshaler.NewJSON(r, unmarshaler.WithCustomFields(userID.Key())).Unmarshal(data)
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if _, ok := userIDFrom(restored); ok {
+		t.Error("want redacted user_id to be unavailable")
+	}
+	for name, value := range restored.UnknownFields() {
+		if name == "user_id" && value != "[REDACTED]" {
+			t.Errorf("want redacted unknown field, got %v", value)
+		}
+	}
+}
+
+func TestWithFieldPresenter(t *testing.T) {
+	m := marshaler.NewJSON(marshaler.WithFieldPresenter(func(key errdef.FieldKey, value errdef.FieldValue) any {
+		return value.Value()
+	}))
+	err := errInternal.WithOptions(password("secret")).New("failed").(errdef.Error)
+	if got := m.Encode(err).Fields["password"]; got != "secret" {
+		t.Errorf("want raw password, got %v", got)
+	}
+}
@@ -0,0 +1,53 @@
+package marshaler
+
+import (
+	"github.com/shiwano/errdef"
+)
+
+// WithoutStack returns an Option that omits the stack traces of the error and
+// its causes.
+func WithoutStack() Option {
+	return func(m *marshaler) {
+		m.stackDepth = 0
+	}
+}
+
+// WithStackDepth returns an Option that limits the number of frames of each
+// stack trace to depth, keeping the frames closest to where the error was created.
+// A negative depth means no limit, which is the default.
+func WithStackDepth(depth int) Option {
+	return func(m *marshaler) {
+		m.stackDepth = depth
+	}
+}
+
+// WithMaxCauseDepth returns an Option that limits the depth of the cause tree.
+// Causes nested deeper than depth are omitted; a depth of 0 omits all causes.
+// A negative depth means no limit, which is the default.
+func WithMaxCauseDepth(depth int) Option {
+	return func(m *marshaler) {
+		m.maxCauseDepth = depth
+	}
+}
+
+// WithRedactedFields returns an Option that redacts the values of the fields
+// with the same names as the given keys, in addition to the fields declared
+// with the Sensitive option. Redacted values are replaced with "[REDACTED]",
+// which unmarshalers keep as unknown fields.
+func WithRedactedFields(keys ...errdef.FieldKey) Option {
+	return func(m *marshaler) {
+		for _, key := range keys {
+			m.redactedFields[key.String()] = struct{}{}
+		}
+	}
+}
+
+// WithFieldPresenter returns an Option that sets the function that returns the
+// value of a field to be encoded. The default is errdef.PresentFieldValue,
+// which redacts the fields declared with the Sensitive option.
+// Fields redacted by WithRedactedFields are not passed to the presenter.
+func WithFieldPresenter(fn func(key errdef.FieldKey, value errdef.FieldValue) any) Option {
+	return func(m *marshaler) {
+		m.presentField = fn
+	}
+}
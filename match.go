@@ -0,0 +1,94 @@
+package errdef
+
+import "errors"
+
+type (
+	// Matcher dispatches an error to the handler of the first matching Definition.
+	// It is created by Match.
+	Matcher struct {
+		err     error
+		matched bool
+	}
+
+	// ValueMatcher dispatches an error to the handler of the first matching
+	// Definition and returns the value produced by the handler.
+	// It is created by MatchValue.
+	ValueMatcher[T any] struct {
+		err     error
+		matched bool
+		value   T
+	}
+)
+
+// Match returns a Matcher for the given error.
+//
+// Cases are evaluated in order with errors.Is, and only the handler of the
+// first matching case is called. If no case matches, the handler passed to
+// Default is called, including when err is nil.
+//
+//	errdef.Match(err).
+//		Case(ErrNotFound, func(err error) { ... }).
+//		Case(ErrConflict, func(err error) { ... }).
+//		Default(func(err error) { ... })
+func Match(err error) *Matcher {
+	return &Matcher{err: err}
+}
+
+// Case calls fn with the error if it matches def and no previous case has matched.
+func (m *Matcher) Case(def Definition, fn func(err error)) *Matcher {
+	if !m.matched && m.err != nil && errors.Is(m.err, def) {
+		m.matched = true
+		fn(m.err)
+	}
+	return m
+}
+
+// Default calls fn with the error if no case has matched.
+func (m *Matcher) Default(fn func(err error)) {
+	if !m.matched {
+		m.matched = true
+		fn(m.err)
+	}
+}
+
+// Matched returns true if any case (or Default) has matched.
+func (m *Matcher) Matched() bool {
+	return m.matched
+}
+
+// MatchValue returns a ValueMatcher for the given error.
+// It works like Match, but each handler returns a value of type T.
+//
+//	status := errdef.MatchValue[int](err).
+//		Case(ErrNotFound, func(err error) int { return http.StatusNotFound }).
+//		Case(ErrConflict, func(err error) int { return http.StatusConflict }).
+//		Default(func(err error) int { return http.StatusInternalServerError })
+func MatchValue[T any](err error) *ValueMatcher[T] {
+	return &ValueMatcher[T]{err: err}
+}
+
+// Case calls fn with the error if it matches def and no previous case has matched,
+// and holds the returned value.
+func (m *ValueMatcher[T]) Case(def Definition, fn func(err error) T) *ValueMatcher[T] {
+	if !m.matched && m.err != nil && errors.Is(m.err, def) {
+		m.matched = true
+		m.value = fn(m.err)
+	}
+	return m
+}
+
+// Default calls fn with the error if no case has matched, and returns
+// the value held by the matched case or returned by fn.
+func (m *ValueMatcher[T]) Default(fn func(err error) T) T {
+	if !m.matched {
+		m.matched = true
+		m.value = fn(m.err)
+	}
+	return m.value
+}
+
+// Value returns the value held by the matched case (or Default).
+// Returns false if nothing has matched yet.
+func (m *ValueMatcher[T]) Value() (T, bool) {
+	return m.value, m.matched
+}
@@ -0,0 +1,95 @@
+package errdef_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shiwano/errdef"
+)
+
+func TestMatch(t *testing.T) {
+	notFound := errdef.Define("not_found")
+	conflict := errdef.Define("conflict")
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"first case", notFound.New("not found"), "not_found"},
+		{"second case", conflict.New("conflict"), "conflict"},
+		{"wrapped", fmt.Errorf("wrap: %w", conflict.New("conflict")), "conflict"},
+		{"default", errors.New("other"), "default"},
+		{"nil", nil, "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			m := errdef.Match(tt.err).
+				Case(notFound, func(err error) { got = append(got, "not_found") }).
+				Case(conflict, func(err error) { got = append(got, "conflict") })
+			m.Default(func(err error) {
+				if err != tt.err {
+					t.Errorf("want %v, got %v", tt.err, err)
+				}
+				got = append(got, "default")
+			})
+
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("want [%s], got %v", tt.want, got)
+			}
+			if !m.Matched() {
+				t.Error("want matched")
+			}
+		})
+	}
+
+	t.Run("first matching case wins", func(t *testing.T) {
+		err := notFound.Wrap(conflict.New("conflict"))
+		var got []string
+		errdef.Match(err).
+			Case(conflict, func(err error) { got = append(got, "conflict") }).
+			Case(notFound, func(err error) { got = append(got, "not_found") })
+
+		if len(got) != 1 || got[0] != "conflict" {
+			t.Errorf("want [conflict], got %v", got)
+		}
+	})
+}
+
+func TestMatchValue(t *testing.T) {
+	notFound := errdef.Define("not_found")
+	conflict := errdef.Define("conflict")
+
+	status := func(err error) int {
+		return errdef.MatchValue[int](err).
+			Case(notFound, func(err error) int { return 404 }).
+			Case(conflict, func(err error) int { return 409 }).
+			Default(func(err error) int { return 500 })
+	}
+
+	if got := status(notFound.New("not found")); got != 404 {
+		t.Errorf("want 404, got %d", got)
+	}
+	if got := status(conflict.New("conflict")); got != 409 {
+		t.Errorf("want 409, got %d", got)
+	}
+	if got := status(errors.New("other")); got != 500 {
+		t.Errorf("want 500, got %d", got)
+	}
+
+	t.Run("value without default", func(t *testing.T) {
+		m := errdef.MatchValue[int](errors.New("other")).
+			Case(notFound, func(err error) int { return 404 })
+		if v, ok := m.Value(); ok || v != 0 {
+			t.Errorf("want 0 and false, got %d and %v", v, ok)
+		}
+
+		m = errdef.MatchValue[int](notFound.New("not found")).
+			Case(notFound, func(err error) int { return 404 })
+		if v, ok := m.Value(); !ok || v != 404 {
+			t.Errorf("want 404 and true, got %d and %v", v, ok)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+// Package msgpack provides a dependency-free MessagePack encoding for errdef errors.
+//
+// An error is encoded as a map with the same keys as its JSON encoding
+// (message, kind, type, fields, stack, and causes), but field values keep
+// their types: integers are decoded as int64 (or uint64 if they do not fit),
+// floats as float64, byte slices as []byte, and time.Time values, encoded
+// with the timestamp extension type, as time.Time. This avoids the float64
+// conversions of JSON described in unmarshaler.DecodedData.
+//
+//	m := msgpack.NewMarshaler()
+//	data, err := m.Marshal(err)
+//
+//	u := msgpack.NewUnmarshaler(resolver)
+//	restored, err := u.Unmarshal(data)
+package msgpack
+
+import (
+	"fmt"
+
+	"github.com/shiwano/errdef/internal/wire"
+	"github.com/shiwano/errdef/marshaler"
+	"github.com/shiwano/errdef/resolver"
+	"github.com/shiwano/errdef/unmarshaler"
+)
+
+var (
+	_ marshaler.Encoder[[]byte]   = Encode
+	_ unmarshaler.Decoder[[]byte] = Decode
+)
+
+// NewMarshaler creates a new Marshaler that marshals errors into MessagePack.
+func NewMarshaler(opts ...marshaler.Option) *marshaler.Marshaler[[]byte] {
+	return marshaler.New(Encode, opts...)
+}
+
+// NewUnmarshaler creates a new Unmarshaler that unmarshals errors from MessagePack.
+func NewUnmarshaler(r resolver.Resolver, opts ...unmarshaler.Option) *unmarshaler.Unmarshaler[[]byte] {
+	return unmarshaler.New(r, Decode, opts...)
+}
+
+// Encode encodes the data of an error into MessagePack.
+// It returns an error if a field value cannot be encoded (e.g., a channel).
+func Encode(data *marshaler.EncodedData) ([]byte, error) {
+	var w writer
+	if err := wire.WriteData(&w, data); err != nil {
+		return nil, fmt.Errorf("msgpack: %w", err)
+	}
+	return w.buf, nil
+}
+
+// Decode decodes MessagePack data into the data of an error.
+func Decode(data []byte) (*unmarshaler.DecodedData, error) {
+	r := reader{data: data}
+	decoded, err := wire.ReadData(&r)
+	if err != nil {
+		return nil, fmt.Errorf("msgpack: %w", err)
+	}
+	if r.pos != len(r.data) {
+		return nil, fmt.Errorf("msgpack: %d trailing bytes", len(r.data)-r.pos)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,228 @@
+package msgpack_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/marshaler"
+	"github.com/shiwano/errdef/msgpack"
+	"github.com/shiwano/errdef/resolver"
+	"github.com/shiwano/errdef/unmarshaler"
+)
+
+type address struct {
+	City string `json:"city"`
+}
+
+var (
+	errNotFound = errdef.Define("not_found", errdef.HTTPStatus(404), errdef.LogLevel(slog.LevelWarn))
+	errInternal = errdef.Define("internal")
+
+	count, countFrom       = errdef.DefineField[int64]("count")
+	big, bigFrom           = errdef.DefineField[uint64]("big")
+	ratio, ratioFrom       = errdef.DefineField[float32]("ratio")
+	timeout, timeoutFrom   = errdef.DefineField[time.Duration]("timeout")
+	at, atFrom             = errdef.DefineField[time.Time]("at")
+	tags, tagsFrom         = errdef.DefineField[[]string]("tags")
+	addr, addrFrom         = errdef.DefineField[address]("addr")
+	payload, payloadFrom   = errdef.DefineField[[]byte]("payload")
+	password, passwordFrom = errdef.DefineField[string]("password", errdef.Sensitive())
+)
+
+func TestRoundTrip(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	orig := errNotFound.WithOptions(
+		count(math.MaxInt64),
+		big(math.MaxUint64),
+		ratio(0.5),
+		timeout(2*time.Second),
+		at(now),
+		tags([]string{"a", "b"}),
+		addr(address{City: "Tokyo"}),
+		payload([]byte{0, 1, 2}),
+		password("secret"),
+	).Wrapf(errInternal.Wrap(io.EOF), "user not found").(errdef.Error)
+
+	data, err := msgpack.NewMarshaler().Marshal(orig)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	r := resolver.New(errNotFound, errInternal)
+	u := msgpack.NewUnmarshaler(r,
+		unmarshaler.WithBuiltinFields(),
+		unmarshaler.WithStandardSentinelErrors(),
+		unmarshaler.WithCustomFields(
+			count.Key(), big.Key(), ratio.Key(), timeout.Key(), at.Key(),
+			tags.Key(), addr.Key(), payload.Key(), password.Key(),
+		),
+	)
+	restored, err := u.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if restored.Error() != orig.Error() {
+		t.Errorf("want message %q, got %q", orig.Error(), restored.Error())
+	}
+	if restored.Kind() != "not_found" {
+		t.Errorf("want kind %q, got %q", "not_found", restored.Kind())
+	}
+	if !reflect.DeepEqual(restored.Stack().Frames(), orig.Stack().Frames()) {
+		t.Errorf("want stack %v, got %v", orig.Stack().Frames(), restored.Stack().Frames())
+	}
+	if !errors.Is(restored, io.EOF) {
+		t.Error("want restored error to wrap io.EOF")
+	}
+
+	check := func(name string, got, want any, ok bool) {
+		t.Helper()
+		if !ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: want %v, got %v (ok=%v)", name, want, got, ok)
+		}
+	}
+	status, ok := errdef.HTTPStatusFrom(restored)
+	check("http_status", status, 404, ok)
+	level, ok := errdef.LogLevelFrom(restored)
+	check("log_level", level, slog.LevelWarn, ok)
+	v1, ok := countFrom(restored)
+	check("count", v1, int64(math.MaxInt64), ok)
+	v2, ok := bigFrom(restored)
+	check("big", v2, uint64(math.MaxUint64), ok)
+	v3, ok := ratioFrom(restored)
+	check("ratio", v3, float32(0.5), ok)
+	v4, ok := timeoutFrom(restored)
+	check("timeout", v4, 2*time.Second, ok)
+	v5, ok := atFrom(restored)
+	check("at", v5, now, ok)
+	v6, ok := tagsFrom(restored)
+	check("tags", v6, []string{"a", "b"}, ok)
+	v7, ok := addrFrom(restored)
+	check("addr", v7, address{City: "Tokyo"}, ok)
+	v8, ok := payloadFrom(restored)
+	check("payload", v8, []byte{0, 1, 2}, ok)
+
+	if _, ok := passwordFrom(restored); ok {
+		t.Error("want redacted password to be unavailable")
+	}
+
+	again, err := msgpack.NewMarshaler().Marshal(restored)
+	if err != nil {
+		t.Fatalf("failed to marshal restored error: %v", err)
+	}
+	if !bytes.Equal(again, data) {
+		t.Errorf("want stable round trip\nwant: %x\ngot:  %x", data, again)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("integer types", func(t *testing.T) {
+		data, err := msgpack.Encode(&marshaler.EncodedData{
+			Message: "m",
+			Fields: map[string]any{
+				"fixint": 1,
+				"neg":    int8(-100),
+				"int32":  int32(math.MinInt32),
+				"uint16": uint16(math.MaxUint16),
+				"uint64": uint64(math.MaxUint64),
+				"float":  1.5,
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to encode: %v", err)
+		}
+		decoded, err := msgpack.Decode(data)
+		if err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		want := map[string]any{
+			"fixint": int64(1),
+			"neg":    int64(-100),
+			"int32":  int64(math.MinInt32),
+			"uint16": int64(math.MaxUint16),
+			"uint64": uint64(math.MaxUint64),
+			"float":  1.5,
+		}
+		if !reflect.DeepEqual(decoded.Fields, want) {
+			t.Errorf("want %v, got %v", want, decoded.Fields)
+		}
+	})
+
+	t.Run("timestamps", func(t *testing.T) {
+		for _, tm := range []time.Time{
+			time.Unix(1700000000, 0).UTC(),
+			time.Unix(1700000000, 123456789).UTC(),
+			time.Unix(-1, 5).UTC(),
+		} {
+			data, _ := msgpack.Encode(&marshaler.EncodedData{Fields: map[string]any{"t": tm}})
+			decoded, err := msgpack.Decode(data)
+			if err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+			if got := decoded.Fields["t"]; got != tm {
+				t.Errorf("want %v, got %v", tm, got)
+			}
+		}
+	})
+
+	t.Run("spec encoding", func(t *testing.T) {
+		// {"message": "hi", "kind": "k"} with str8 and fixstr.
+		data := []byte{0x82, 0xa7, 'm', 'e', 's', 's', 'a', 'g', 'e', 0xd9, 0x02, 'h', 'i', 0xa4, 'k', 'i', 'n', 'd', 0xa1, 'k'}
+		decoded, err := msgpack.Decode(data)
+		if err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		if decoded.Message != "hi" || decoded.Kind != "k" {
+			t.Errorf("want hi/k, got %s/%s", decoded.Message, decoded.Kind)
+		}
+	})
+
+	// field encodes {"fields": {"f": v}}.
+	field := func(v ...byte) []byte {
+		return append([]byte{0x81, 0xa6, 'f', 'i', 'e', 'l', 'd', 's', 0x81, 0xa1, 'f'}, v...)
+	}
+	// {"causes": [...]} nested beyond the maximum depth.
+	deepCauses := bytes.Repeat([]byte{0x81, 0xa6, 'c', 'a', 'u', 's', 'e', 's', 0x91}, 1100)
+
+	errorTests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "empty", data: nil, want: "unexpected end of data"},
+		{name: "truncated", data: []byte{0x81, 0xa7, 'm'}, want: "unexpected end of data"},
+		{name: "trailing", data: []byte{0x80, 0xc0}, want: "1 trailing bytes"},
+		{name: "not a map", data: []byte{0x01}, want: "want map, got format byte 0x01 at offset 0"},
+		{name: "non-string key", data: []byte{0x81, 0x01, 0x01}, want: "want string, got format byte 0x01 at offset 1"},
+		{name: "wrong message type", data: []byte{0x81, 0xa7, 'm', 'e', 's', 's', 'a', 'g', 'e', 0x01}, want: "message: want string"},
+		{name: "huge map", data: []byte{0xdf, 0xff, 0xff, 0xff, 0xff}, want: "unexpected end of data"},
+		{name: "deep causes", data: deepCauses, want: "nesting depth exceeds"},
+		{name: "invalid format", data: field(0xc1), want: "invalid format byte 0xc1"},
+		{name: "non-string field key", data: field(0x81, 0x01, 0x01), want: "unsupported map key"},
+		{name: "huge array", data: field(0xdd, 0xff, 0xff, 0xff, 0xff), want: "unexpected end of data"},
+		{name: "deep nesting", data: field(bytes.Repeat([]byte{0x91}, 2000)...), want: "nesting depth exceeds"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := msgpack.Decode(tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("want error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestEncode_UnsupportedType(t *testing.T) {
+	_, err := msgpack.Encode(&marshaler.EncodedData{Fields: map[string]any{"ch": make(chan int)}})
+	if err == nil || !strings.Contains(err.Error(), `field "ch": unsupported type chan int`) {
+		t.Errorf("want unsupported type error, got %v", err)
+	}
+}
@@ -0,0 +1,352 @@
+package msgpack
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shiwano/errdef/internal/wire"
+)
+
+// reader reads values in the MessagePack format into Go values:
+// nil, bool, int64, uint64, float64, string, []byte, time.Time, []any, and
+// map[string]any.
+type reader struct {
+	data []byte
+	pos  int
+}
+
+var _ wire.Reader = (*reader)(nil)
+
+var errUnexpectedEOF = errors.New("unexpected end of data")
+
+func (r *reader) ReadValue() (any, error) {
+	return r.readValue(0)
+}
+
+func (r *reader) ReadNil() (bool, error) {
+	if r.pos >= len(r.data) {
+		return false, errUnexpectedEOF
+	}
+	if r.data[r.pos] != 0xc0 {
+		return false, nil
+	}
+	r.pos++
+	return true, nil
+}
+
+// ReadBreak always returns false, since MessagePack has no indefinite lengths.
+func (r *reader) ReadBreak() (bool, error) {
+	return false, nil
+}
+
+func (r *reader) ReadMapHeader() (int, error) {
+	b, err := r.readByte()
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	switch {
+	case b&0xf0 == 0x80:
+		n = int(b & 0x0f)
+	case b == 0xde || b == 0xdf:
+		v, err := r.readUint(2 << (b - 0xde))
+		if err != nil {
+			return 0, err
+		}
+		n = int(v)
+	default:
+		return 0, r.typeError("map", b)
+	}
+	if n > (len(r.data)-r.pos)/2 {
+		return 0, errUnexpectedEOF // each entry takes at least two bytes
+	}
+	return n, nil
+}
+
+func (r *reader) ReadArrayHeader() (int, error) {
+	b, err := r.readByte()
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	switch {
+	case b&0xf0 == 0x90:
+		n = int(b & 0x0f)
+	case b == 0xdc || b == 0xdd:
+		v, err := r.readUint(2 << (b - 0xdc))
+		if err != nil {
+			return 0, err
+		}
+		n = int(v)
+	default:
+		return 0, r.typeError("array", b)
+	}
+	if n > len(r.data)-r.pos {
+		return 0, errUnexpectedEOF // each element takes at least one byte
+	}
+	return n, nil
+}
+
+func (r *reader) ReadStringBytes() ([]byte, error) {
+	b, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case b&0xe0 == 0xa0:
+		return r.readN(int(b & 0x1f))
+	case b >= 0xd9 && b <= 0xdb:
+		n, err := r.readUint(1 << (b - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return r.readN(int(n))
+	}
+	return nil, r.typeError("string", b)
+}
+
+func (r *reader) ReadInt() (int64, error) {
+	b, err := r.readByte()
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case b <= 0x7f:
+		return int64(b), nil
+	case b >= 0xe0:
+		return int64(int8(b)), nil
+	case b >= 0xcc && b <= 0xcf:
+		v, err := r.readUint(1 << (b - 0xcc))
+		if err != nil {
+			return 0, err
+		}
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("integer %d overflows int64", v)
+		}
+		return int64(v), nil
+	case b >= 0xd0 && b <= 0xd3:
+		n := 1 << (b - 0xd0)
+		v, err := r.readUint(n)
+		if err != nil {
+			return 0, err
+		}
+		shift := 64 - 8*n
+		return int64(v<<shift) >> shift, nil
+	}
+	return 0, r.typeError("integer", b)
+}
+
+// typeError returns an error for the format byte b, which has just been read,
+// that is not of the wanted type.
+func (r *reader) typeError(want string, b byte) error {
+	return fmt.Errorf("want %s, got format byte 0x%02x at offset %d", want, b, r.pos-1)
+}
+
+func (r *reader) readValue(depth int) (any, error) {
+	if depth > wire.MaxDepth {
+		return nil, fmt.Errorf("nesting depth exceeds %d", wire.MaxDepth)
+	}
+
+	b, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case b <= 0x7f:
+		return int64(b), nil
+	case b >= 0xe0:
+		return int64(int8(b)), nil
+	case b&0xe0 == 0xa0:
+		return r.readString(int(b & 0x1f))
+	case b&0xf0 == 0x90:
+		return r.readArray(int(b&0x0f), depth)
+	case b&0xf0 == 0x80:
+		return r.readMap(int(b&0x0f), depth)
+	}
+
+	switch b {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		v, err := r.readUint(1 << (b - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		if v > math.MaxInt64 {
+			return v, nil
+		}
+		return int64(v), nil
+	case 0xd0:
+		v, err := r.readUint(1)
+		return int64(int8(v)), err
+	case 0xd1:
+		v, err := r.readUint(2)
+		return int64(int16(v)), err
+	case 0xd2:
+		v, err := r.readUint(4)
+		return int64(int32(v)), err
+	case 0xd3:
+		v, err := r.readUint(8)
+		return int64(v), err
+	case 0xca:
+		v, err := r.readUint(4)
+		return float64(math.Float32frombits(uint32(v))), err
+	case 0xcb:
+		v, err := r.readUint(8)
+		return math.Float64frombits(v), err
+	case 0xd9, 0xda, 0xdb:
+		n, err := r.readUint(1 << (b - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return r.readString(int(n))
+	case 0xc4, 0xc5, 0xc6:
+		n, err := r.readUint(1 << (b - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		data, err := r.readN(int(n))
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), data...), nil
+	case 0xdc, 0xdd:
+		n, err := r.readUint(2 << (b - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return r.readArray(int(n), depth)
+	case 0xde, 0xdf:
+		n, err := r.readUint(2 << (b - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return r.readMap(int(n), depth)
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return r.readExt(1 << (b - 0xd4))
+	case 0xc7, 0xc8, 0xc9:
+		n, err := r.readUint(1 << (b - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return r.readExt(int(n))
+	}
+	return nil, fmt.Errorf("invalid format byte 0x%02x", b)
+}
+
+func (r *reader) readString(n int) (string, error) {
+	data, err := r.readN(n)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (r *reader) readArray(n, depth int) ([]any, error) {
+	if n > len(r.data)-r.pos {
+		return nil, errUnexpectedEOF // each element takes at least one byte
+	}
+	values := make([]any, n)
+	for i := range values {
+		v, err := r.readValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func (r *reader) readMap(n, depth int) (map[string]any, error) {
+	if n > (len(r.data)-r.pos)/2 {
+		return nil, errUnexpectedEOF // each entry takes at least two bytes
+	}
+	m := make(map[string]any, n)
+	for range n {
+		k, err := r.readValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("unsupported map key of type %T", k)
+		}
+		v, err := r.readValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+	}
+	return m, nil
+}
+
+// readExt reads an extension value of n bytes after its type.
+// Only the timestamp extension type (-1) is supported.
+func (r *reader) readExt(n int) (any, error) {
+	typ, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.readN(n)
+	if err != nil {
+		return nil, err
+	}
+	if int8(typ) != -1 {
+		return nil, fmt.Errorf("unsupported extension type %d", int8(typ))
+	}
+
+	switch n {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(data)), 0).UTC(), nil
+	case 8:
+		v := binary.BigEndian.Uint64(data)
+		return time.Unix(int64(v&(1<<34-1)), int64(v>>34)).UTC(), nil
+	case 12:
+		nsec := binary.BigEndian.Uint32(data)
+		sec := int64(binary.BigEndian.Uint64(data[4:]))
+		return time.Unix(sec, int64(nsec)).UTC(), nil
+	}
+	return nil, fmt.Errorf("invalid timestamp length %d", n)
+}
+
+func (r *reader) readByte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errUnexpectedEOF
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *reader) readUint(n int) (uint64, error) {
+	data, err := r.readN(n)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, b := range data {
+		v = v<<8 | uint64(b)
+	}
+	return v, nil
+}
+
+func (r *reader) readN(n int) ([]byte, error) {
+	if n < 0 || n > len(r.data)-r.pos {
+		return nil, errUnexpectedEOF
+	}
+	data := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return data, nil
+}
@@ -0,0 +1,134 @@
+package msgpack
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/shiwano/errdef/internal/wire"
+)
+
+// writer writes values in the MessagePack format, using the smallest
+// representation of each value.
+type writer struct {
+	buf []byte
+}
+
+var _ wire.Writer = (*writer)(nil)
+
+func (w *writer) WriteNil() {
+	w.buf = append(w.buf, 0xc0)
+}
+
+func (w *writer) WriteBool(v bool) {
+	if v {
+		w.buf = append(w.buf, 0xc3)
+	} else {
+		w.buf = append(w.buf, 0xc2)
+	}
+}
+
+func (w *writer) WriteInt(v int64) {
+	switch {
+	case v >= 0:
+		w.WriteUint(uint64(v))
+	case v >= -32:
+		w.buf = append(w.buf, byte(v)) // negative fixint
+	case v >= math.MinInt8:
+		w.buf = append(w.buf, 0xd0, byte(v))
+	case v >= math.MinInt16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xd1), uint16(v))
+	case v >= math.MinInt32:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xd2), uint32(v))
+	default:
+		w.buf = binary.BigEndian.AppendUint64(append(w.buf, 0xd3), uint64(v))
+	}
+}
+
+func (w *writer) WriteUint(v uint64) {
+	switch {
+	case v <= 0x7f:
+		w.buf = append(w.buf, byte(v)) // positive fixint
+	case v <= math.MaxUint8:
+		w.buf = append(w.buf, 0xcc, byte(v))
+	case v <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xcd), uint16(v))
+	case v <= math.MaxUint32:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xce), uint32(v))
+	default:
+		w.buf = binary.BigEndian.AppendUint64(append(w.buf, 0xcf), v)
+	}
+}
+
+func (w *writer) WriteFloat32(v float32) {
+	w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xca), math.Float32bits(v))
+}
+
+func (w *writer) WriteFloat64(v float64) {
+	w.buf = binary.BigEndian.AppendUint64(append(w.buf, 0xcb), math.Float64bits(v))
+}
+
+func (w *writer) WriteString(v string) {
+	n := len(v)
+	switch {
+	case n < 32:
+		w.buf = append(w.buf, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		w.buf = append(w.buf, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xda), uint16(n))
+	default:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xdb), uint32(n))
+	}
+	w.buf = append(w.buf, v...)
+}
+
+func (w *writer) WriteBytes(v []byte) {
+	n := len(v)
+	switch {
+	case n <= math.MaxUint8:
+		w.buf = append(w.buf, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xc5), uint16(n))
+	default:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xc6), uint32(n))
+	}
+	w.buf = append(w.buf, v...)
+}
+
+// WriteTime writes v with the timestamp extension type (-1), in the 32-bit,
+// 64-bit, or 96-bit format.
+func (w *writer) WriteTime(v time.Time) {
+	sec, nsec := v.Unix(), uint32(v.Nanosecond())
+	switch {
+	case sec >= 0 && sec <= math.MaxUint32 && nsec == 0:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xd6, 0xff), uint32(sec))
+	case sec >= 0 && sec < 1<<34:
+		w.buf = binary.BigEndian.AppendUint64(append(w.buf, 0xd7, 0xff), uint64(nsec)<<34|uint64(sec))
+	default:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xc7, 12, 0xff), nsec)
+		w.buf = binary.BigEndian.AppendUint64(w.buf, uint64(sec))
+	}
+}
+
+func (w *writer) WriteArrayHeader(n int) {
+	switch {
+	case n < 16:
+		w.buf = append(w.buf, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xdc), uint16(n))
+	default:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xdd), uint32(n))
+	}
+}
+
+func (w *writer) WriteMapHeader(n int) {
+	switch {
+	case n < 16:
+		w.buf = append(w.buf, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xde), uint16(n))
+	default:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xdf), uint32(n))
+	}
+}
@@ -27,15 +27,22 @@ type (
 	}
 
 	jsonCauseData struct {
-		Message string  `json:"message"`
-		Type    string  `json:"type"`
-		Causes  []*Node `json:"causes,omitempty"`
+		Message string     `json:"message"`
+		Type    string     `json:"type"`
+		Causes  []jsonNode `json:"causes,omitempty"`
+	}
+
+	// jsonNode marshals a node with the scrubber inherited from its ancestors.
+	jsonNode struct {
+		node  *Node
+		scrub Scrubber
 	}
 )
 
 var (
 	_ json.Marshaler = (*Node)(nil)
 	_ slog.LogValuer = (*Node)(nil)
+	_ json.Marshaler = jsonNode{}
 )
 
 // Walk returns an iterator that traverses the error tree in depth-first order.
@@ -65,30 +72,61 @@ func (ns Nodes) HasCycle() bool {
 
 // MarshalJSON implements json.Marshaler for Node.
 func (n *Node) MarshalJSON() ([]byte, error) {
+	return marshalNodeJSON(n, loadGlobalScrubber())
+}
+
+// LogValue implements slog.LogValuer for Node.
+func (e *Node) LogValue() slog.Value {
+	return makeNodeLogValue(e, loadGlobalScrubber())
+}
+
+func (n jsonNode) MarshalJSON() ([]byte, error) {
+	return marshalNodeJSON(n.node, n.scrub)
+}
+
+func newJSONNodes(nodes Nodes, scrub Scrubber) []jsonNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+	result := make([]jsonNode, len(nodes))
+	for i, n := range nodes {
+		result[i] = jsonNode{node: n, scrub: scrub}
+	}
+	return result
+}
+
+func marshalNodeJSON(n *Node, scrub Scrubber) ([]byte, error) {
 	switch err := n.Error.(type) {
+	case *definedError:
+		if err.def.jsonMarshaler != nil {
+			return json.Marshal(err)
+		}
+		return marshalErrorJSON(err, scrub)
 	case Error:
 		return json.Marshal(err)
 	case interface{ TypeName() string }:
 		return json.Marshal(jsonCauseData{
-			Message: n.Error.Error(),
+			Message: presentScrubber(scrub, n.Error).apply(n.Error.Error()),
 			Type:    err.TypeName(),
-			Causes:  n.Causes,
+			Causes:  newJSONNodes(n.Causes, scrub),
 		})
 	default:
 		return json.Marshal(jsonCauseData{
-			Message: n.Error.Error(),
+			Message: presentScrubber(scrub, n.Error).apply(n.Error.Error()),
 			Type:    fmt.Sprintf("%T", n.Error),
-			Causes:  n.Causes,
+			Causes:  newJSONNodes(n.Causes, scrub),
 		})
 	}
 }
 
-// LogValue implements slog.LogValuer for Node.
-func (e *Node) LogValue() slog.Value {
-	switch err := e.Error.(type) {
+func makeNodeLogValue(n *Node, inherited Scrubber) slog.Value {
+	msgScrub := presentScrubber(inherited, n.Error)
+	scrub := ChainScrubbers(inherited, ownScrubber(n.Error))
+
+	switch err := n.Error.(type) {
 	case Error:
 		attrs := make([]slog.Attr, 0, 5)
-		attrs = append(attrs, slog.String("message", err.Error()))
+		attrs = append(attrs, slog.String("message", msgScrub.apply(err.Error())))
 
 		if err.Kind() != "" {
 			attrs = append(attrs, slog.String("kind", string(err.Kind())))
@@ -99,22 +137,22 @@ func (e *Node) LogValue() slog.Value {
 		if err.Stack().Len() > 0 {
 			attrs = append(attrs, slog.Any("stack", err.Stack()))
 		}
-		if len(e.Causes) > 0 {
-			causes := make([]any, len(e.Causes))
-			for i, cause := range e.Causes {
-				causes[i] = slogValueToAny(cause.LogValue())
+		if len(n.Causes) > 0 {
+			causes := make([]any, len(n.Causes))
+			for i, cause := range n.Causes {
+				causes[i] = slogValueToAny(makeNodeLogValue(cause, scrub))
 			}
 			attrs = append(attrs, slog.Any("causes", causes))
 		}
 		return slog.GroupValue(attrs...)
 	default:
 		attrs := make([]slog.Attr, 0, 2)
-		attrs = append(attrs, slog.String("message", err.Error()))
+		attrs = append(attrs, slog.String("message", msgScrub.apply(err.Error())))
 
-		if len(e.Causes) > 0 {
-			causes := make([]any, len(e.Causes))
-			for i, cause := range e.Causes {
-				causes[i] = slogValueToAny(cause.LogValue())
+		if len(n.Causes) > 0 {
+			causes := make([]any, len(n.Causes))
+			for i, cause := range n.Causes {
+				causes[i] = slogValueToAny(makeNodeLogValue(cause, scrub))
 			}
 			attrs = append(attrs, slog.Any("causes", causes))
 		}
@@ -13,6 +13,11 @@ type (
 		applyOption(d *definition)
 	}
 
+	// FieldOption represents a configuration option that can be applied to field definitions.
+	FieldOption interface {
+		applyFieldOption(k *fieldKeyConfig)
+	}
+
 	// FieldConstructor creates an Option that sets a field value.
 	FieldConstructor[T any] func(value T) Option
 
@@ -32,6 +37,10 @@ type (
 
 	noopOption struct{}
 
+	sensitive struct{}
+
+	publicField struct{}
+
 	noTrace struct{}
 
 	stackSkip struct {
@@ -47,6 +56,14 @@ type (
 		depth  int
 	}
 
+	repanicWhen struct {
+		fn func(panicValue any) bool
+	}
+
+	messageScrubber struct {
+		scrubber Scrubber
+	}
+
 	formatter struct {
 		formatter func(err Error, s fmt.State, verb rune)
 	}
@@ -158,6 +175,14 @@ func (o *field[T]) applyOption(d *definition) {
 
 func (o *noopOption) applyOption(d *definition) {}
 
+func (o *sensitive) applyFieldOption(k *fieldKeyConfig) {
+	k.sensitive = true
+}
+
+func (o *publicField) applyFieldOption(k *fieldKeyConfig) {
+	k.public = true
+}
+
 func (o *noTrace) applyOption(d *definition) {
 	d.noTrace = true
 }
@@ -175,6 +200,17 @@ func (o *stackSource) applyOption(d *definition) {
 	d.stackSourceDepth = o.depth
 }
 
+func (o *repanicWhen) applyOption(d *definition) {
+	d.repanicWhen = o.fn
+}
+
+func (o *messageScrubber) applyOption(d *definition) {
+	d.messageScrubber = o.scrubber
+	if o.scrubber != nil {
+		definitionScrubberUsed.Store(true)
+	}
+}
+
 func (o *formatter) applyOption(d *definition) {
 	d.formatter = o.formatter
 }
@@ -13,6 +13,7 @@ var (
 	public, publicFrom             = DefineField[bool]("public")
 	retryable, retryableFrom       = DefineField[bool]("retryable")
 	unreportable, unreportableFrom = DefineField[bool]("unreportable")
+	bug, bugFrom                   = DefineField[bool]("bug")
 )
 
 var (
@@ -23,13 +24,13 @@ var (
 	LogLevel, LogLevelFrom = DefineField[slog.Level]("log_level")
 
 	// TraceID attaches a trace or request ID.
-	TraceID, TraceIDFrom = DefineField[string]("trace_id")
+	TraceID, TraceIDFrom = DefineField[string]("trace_id", PublicField())
 
 	// Domain labels the error with a service or subsystem name.
 	Domain, DomainFrom = DefineField[string]("domain")
 
 	// Provides a safe, user-facing hint message.
-	UserHint, UserHintFrom = DefineField[string]("user_hint")
+	UserHint, UserHintFrom = DefineField[string]("user_hint", PublicField())
 
 	// Public marks the error as safe to expose externally.
 	Public, IsPublic = public.WithValue(true), publicFrom.WithZero()
@@ -38,21 +39,38 @@ var (
 	Retryable, IsRetryable = retryable.WithValue(true), retryableFrom.WithZero()
 
 	// RetryAfter recommends a delay to wait before retrying.
-	RetryAfter, RetryAfterFrom = DefineField[time.Duration]("retry_after")
+	RetryAfter, RetryAfterFrom = DefineField[time.Duration]("retry_after", PublicField())
 
 	// Unreportable prevents the error from being sent to error tracking.
 	Unreportable, IsUnreportable = unreportable.WithValue(true), unreportableFrom.WithZero()
 
+	// Bug marks the error as a programming bug (e.g., a recovered runtime panic).
+	Bug, IsBug = bug.WithValue(true), bugFrom.WithZero()
+
 	// ExitCode sets the exit code for a CLI application.
 	ExitCode, ExitCodeFrom = DefineField[int]("exit_code")
 
 	// HelpURL provides a URL for documentation or help guides.
-	HelpURL, HelpURLFrom = DefineField[string]("help_url")
+	HelpURL, HelpURLFrom = DefineField[string]("help_url", PublicField())
 
 	// DetailsFrom extracts diagnostic details from an error.
 	DetailsFrom FieldExtractor[Details] = detailsFrom
 )
 
+// Sensitive marks the field as sensitive. Its value is stored as is and remains
+// available to extractors, but is rendered as "[REDACTED]" when the error is
+// printed, marshaled, or logged (fmt, json, slog), unless unsafe mode is enabled.
+func Sensitive() FieldOption {
+	return &sensitive{}
+}
+
+// PublicField marks the field as safe to expose externally.
+// Only the values of public fields are included in the result of PublicView.
+// The built-in TraceID, UserHint, RetryAfter, and HelpURL fields are public.
+func PublicField() FieldOption {
+	return &publicField{}
+}
+
 // NoTrace disables stack trace collection for the error.
 func NoTrace() Option {
 	return &noTrace{}
@@ -84,6 +102,21 @@ func StackSource(around, depth int) Option {
 	return &stackSource{around: around, depth: depth}
 }
 
+// RepanicWhen sets a policy that decides which recovered panic values are
+// re-raised by Recover instead of being converted into errors.
+// http.ErrAbortHandler is always re-raised regardless of this policy.
+func RepanicWhen(fn func(panicValue any) bool) Option {
+	return &repanicWhen{fn: fn}
+}
+
+// MessageScrubber sets a scrubber that removes sensitive data from messages
+// of the error and its whole cause tree when presented (fmt, json, slog).
+// It also applies to the messages of errors wrapping the error, which embed its message.
+// It is applied after the global scrubber set by SetMessageScrubber.
+func MessageScrubber(s Scrubber) Option {
+	return &messageScrubber{scrubber: s}
+}
+
 // Formatter overrides the default `fmt.Formatter` behavior.
 func Formatter(f func(err Error, s fmt.State, verb rune)) Option {
 	return &formatter{formatter: f}
@@ -1,6 +1,7 @@
 package errdef_test
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"reflect"
@@ -120,6 +121,15 @@ func TestUnreportable(t *testing.T) {
 	}
 }
 
+func TestBug(t *testing.T) {
+	def := errdef.Define("test_error", errdef.Bug())
+	err := def.New("test error")
+
+	if !errdef.IsBug(err) {
+		t.Error("want error to be a bug")
+	}
+}
+
 func TestExitCode(t *testing.T) {
 	want := 42
 	def := errdef.Define("test_error", errdef.ExitCode(want))
@@ -242,6 +252,36 @@ func TestStackDepth(t *testing.T) {
 	})
 }
 
+func TestRepanicWhen(t *testing.T) {
+	errFatal := errors.New("fatal")
+	def := errdef.Define("test_error", errdef.RepanicWhen(func(panicValue any) bool {
+		return panicValue == errFatal
+	}))
+
+	t.Run("re-panics matched value", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != errFatal {
+				t.Errorf("want re-panic with %v, got %v", errFatal, r)
+			}
+		}()
+
+		_ = def.Recover(func() error {
+			panic(errFatal)
+		})
+		t.Error("want Recover to re-panic")
+	})
+
+	t.Run("recovers other values", func(t *testing.T) {
+		err := def.Recover(func() error {
+			panic("other")
+		})
+
+		if !errors.Is(err, def) {
+			t.Errorf("want error to be wrapped by the definition, got %v", err)
+		}
+	})
+}
+
 func TestFormatter(t *testing.T) {
 	customFormatter := func(err errdef.Error, s fmt.State, verb rune) {
 		_, _ = fmt.Fprintf(s, "CUSTOM: %s", err.Error())
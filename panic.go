@@ -1,31 +1,68 @@
 package errdef
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"runtime"
 )
 
 type (
+	// PanicError represents a panic recovered by Recover and its variants.
 	PanicError interface {
 		error
 
 		// PanicValue returns the value recovered from the panic.
 		PanicValue() any
+		// Class returns the classification of the panic value.
+		Class() PanicClass
 		// Unwrap returns the underlying error if the panic value is an error.
 		Unwrap() error
 	}
 
+	// PanicClass classifies a recovered panic value.
+	PanicClass int
+
 	panicError struct {
 		msg        string
 		panicValue any
 	}
 )
 
+const (
+	// PanicClassValue indicates that the panic value is an arbitrary non-error value.
+	PanicClassValue PanicClass = iota
+	// PanicClassError indicates that the panic value is an error.
+	PanicClassError
+	// PanicClassRuntimeError indicates that the panic value is a runtime.Error,
+	// such as a nil pointer dereference or an index out of range.
+	PanicClassRuntimeError
+)
+
+// ErrGoexit is reported by Go and Group when the function calls runtime.Goexit
+// instead of returning normally.
+var ErrGoexit = errors.New("runtime.Goexit was called")
+
 var (
 	_ PanicError    = (*panicError)(nil)
 	_ fmt.Formatter = (*panicError)(nil)
+	_ fmt.Stringer  = PanicClass(0)
 )
 
+// String returns the name of the panic class.
+func (c PanicClass) String() string {
+	switch c {
+	case PanicClassValue:
+		return "value"
+	case PanicClassError:
+		return "error"
+	case PanicClassRuntimeError:
+		return "runtime_error"
+	default:
+		return "unknown"
+	}
+}
+
 func newPanicError(panicValue any) *panicError {
 	return &panicError{
 		msg:        fmt.Sprintf("%v", panicValue),
@@ -41,6 +78,17 @@ func (e *panicError) PanicValue() any {
 	return e.panicValue
 }
 
+func (e *panicError) Class() PanicClass {
+	switch e.panicValue.(type) {
+	case runtime.Error:
+		return PanicClassRuntimeError
+	case error:
+		return PanicClassError
+	default:
+		return PanicClassValue
+	}
+}
+
 func (e *panicError) Unwrap() error {
 	if err, ok := e.panicValue.(error); ok {
 		return err
@@ -42,6 +42,66 @@ func TestPanicError_PanicValue(t *testing.T) {
 	}
 }
 
+func TestPanicError_Class(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+		want errdef.PanicClass
+	}{
+		{
+			name: "with runtime error",
+			fn: func() {
+				var m map[string]int
+				m["key"] = 1
+			},
+			want: errdef.PanicClassRuntimeError,
+		},
+		{
+			name: "with standard error",
+			fn:   func() { panic(errors.New("panic error")) },
+			want: errdef.PanicClassError,
+		},
+		{
+			name: "with string value",
+			fn:   func() { panic("panic string") },
+			want: errdef.PanicClassValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := errdef.Define("test_error")
+			err := def.Recover(func() error {
+				tt.fn()
+				return nil
+			})
+
+			var panicErr errdef.PanicError
+			if !errors.As(err, &panicErr) {
+				t.Fatal("want error to be a PanicError")
+			}
+
+			if got := panicErr.Class(); got != tt.want {
+				t.Errorf("want class %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPanicClass_String(t *testing.T) {
+	tests := map[errdef.PanicClass]string{
+		errdef.PanicClassValue:        "value",
+		errdef.PanicClassError:        "error",
+		errdef.PanicClassRuntimeError: "runtime_error",
+		errdef.PanicClass(99):         "unknown",
+	}
+	for class, want := range tests {
+		if got := class.String(); got != want {
+			t.Errorf("want %q, got %q", want, got)
+		}
+	}
+}
+
 func TestPanicError_Unwrap(t *testing.T) {
 	t.Run("with error", func(t *testing.T) {
 		def := errdef.Define("test_error")
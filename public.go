@@ -0,0 +1,218 @@
+package errdef
+
+import (
+	"encoding/json"
+	"maps"
+	"strings"
+)
+
+type (
+	// PublicErrorView is a sanitized projection of an error tree that is safe
+	// to expose to external clients. It holds no stack traces and no internal causes.
+	PublicErrorView struct {
+		// Message is the own message of the nearest public error, without the
+		// messages of its causes, with message scrubbers applied.
+		Message string `json:"message"`
+		// Kind is the kind of the nearest public error.
+		Kind Kind `json:"kind,omitempty"`
+		// HTTPStatus is the HTTP status code attached to the public error, or 0 if none.
+		HTTPStatus int `json:"-"`
+		// Fields holds the values of the fields declared with the PublicField option.
+		Fields map[string]any `json:"fields,omitempty"`
+	}
+
+	// ProblemDetails represents an RFC 9457 Problem Details object.
+	// Extensions are marshaled as top-level members alongside the standard ones.
+	ProblemDetails struct {
+		Type       string         `json:"type,omitempty"`
+		Title      string         `json:"title,omitempty"`
+		Status     int            `json:"status,omitempty"`
+		Detail     string         `json:"detail,omitempty"`
+		Instance   string         `json:"instance,omitempty"`
+		Extensions map[string]any `json:"-"`
+	}
+)
+
+var (
+	_ json.Marshaler = ProblemDetails{}
+)
+
+// PublicView returns a sanitized view of err for external exposure.
+//
+// It finds the nearest error marked with the Public option by traversing the
+// cause tree in depth-first order, and keeps only its message, kind, HTTP status,
+// and the fields declared with the PublicField option, found on the public error
+// or on the errors wrapping it (the nearest one wins).
+//
+// The message excludes the messages of the causes (e.g., "cannot create user"
+// for an error created with Wrapf(dbErr, "cannot create user")), so it is empty
+// for an error created with Wrap or Join.
+//
+// If no public error is found, it returns false along with a view that holds
+// only the public fields of err itself, so callers can still respond with
+// values such as the trace ID next to a generic message. The view is never nil.
+func PublicView(err error) (*PublicErrorView, bool) {
+	if err == nil {
+		return &PublicErrorView{}, false
+	}
+	root, ok := buildNode(err, make(map[uintptr]uintptr))
+	if !ok {
+		return &PublicErrorView{}, false
+	}
+
+	path, found := findPublicPath(root, nil)
+	if !found {
+		path = []*Node{root}
+	}
+
+	view := &PublicErrorView{}
+	for i := len(path) - 1; i >= 0; i-- {
+		e, ok := path[i].Error.(Error)
+		if !ok {
+			continue
+		}
+		for k, v := range e.Fields().All() {
+			if _, exists := view.Fields[k.String()]; exists || !isPublicKey(k) {
+				continue
+			}
+			if view.Fields == nil {
+				view.Fields = make(map[string]any)
+			}
+			view.Fields[k.String()] = PresentFieldValue(k, v)
+		}
+		if view.HTTPStatus == 0 {
+			if v, ok := e.Fields().Get(HTTPStatus.Key()); ok {
+				view.HTTPStatus, _ = v.Value().(int)
+			}
+		}
+	}
+	if !found {
+		return view, false
+	}
+
+	e := path[len(path)-1].Error.(Error)
+	view.Message = presentScrubber(loadGlobalScrubber(), e).apply(ownMessage(e))
+	view.Kind = e.Kind()
+	return view, true
+}
+
+// ProblemDetails converts the view into an RFC 9457 Problem Details object.
+// The kind is used as the title, the message as the detail, and the
+// help URL (if public) as the type. The kind and the public fields are
+// added as extension members.
+func (v *PublicErrorView) ProblemDetails() ProblemDetails {
+	p := ProblemDetails{
+		Type:       "about:blank",
+		Title:      string(v.Kind),
+		Status:     v.HTTPStatus,
+		Detail:     v.Message,
+		Extensions: maps.Clone(v.Fields),
+	}
+	if u, ok := v.Fields[HelpURL.Key().String()].(string); ok && u != "" {
+		p.Type = u
+		delete(p.Extensions, HelpURL.Key().String())
+	}
+	if v.Kind != "" {
+		if p.Extensions == nil {
+			p.Extensions = make(map[string]any)
+		}
+		p.Extensions["kind"] = string(v.Kind)
+	}
+	return p
+}
+
+// GRPCCode returns the gRPC status code corresponding to the view's HTTP status,
+// following the mapping used by gRPC-Gateway. It returns Unknown (2) if the
+// status is not set or has no corresponding code.
+// The result can be converted with codes.Code(v.GRPCCode()).
+func (v *PublicErrorView) GRPCCode() uint32 {
+	switch v.HTTPStatus {
+	case 200:
+		return 0 // OK
+	case 400:
+		return 3 // InvalidArgument
+	case 401:
+		return 16 // Unauthenticated
+	case 403:
+		return 7 // PermissionDenied
+	case 404:
+		return 5 // NotFound
+	case 409:
+		return 10 // Aborted
+	case 412:
+		return 9 // FailedPrecondition
+	case 416:
+		return 11 // OutOfRange
+	case 429:
+		return 8 // ResourceExhausted
+	case 499:
+		return 1 // Canceled
+	case 500:
+		return 13 // Internal
+	case 501:
+		return 12 // Unimplemented
+	case 503:
+		return 14 // Unavailable
+	case 504:
+		return 4 // DeadlineExceeded
+	default:
+		return 2 // Unknown
+	}
+}
+
+// MarshalJSON implements json.Marshaler for ProblemDetails.
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(p.Extensions)+5)
+	maps.Copy(m, p.Extensions)
+	if p.Type != "" {
+		m["type"] = p.Type
+	}
+	if p.Title != "" {
+		m["title"] = p.Title
+	}
+	if p.Status != 0 {
+		m["status"] = p.Status
+	}
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
+
+// findPublicPath returns the nodes from n down to the nearest public error.
+func findPublicPath(n *Node, path []*Node) ([]*Node, bool) {
+	path = append(path, n)
+	if e, ok := n.Error.(Error); ok {
+		if v, ok := e.Fields().Get(public.Key()); ok && v.Equal(true) {
+			return path, true
+		}
+	}
+	for _, c := range n.Causes {
+		if p, ok := findPublicPath(c, path); ok {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// ownMessage returns the message of e without the message of its cause,
+// which Wrap, Wrapf, and Join include.
+func ownMessage(e Error) string {
+	de, ok := e.(*definedError)
+	if !ok || de.cause == nil {
+		return e.Error()
+	}
+	cause := de.cause.Error()
+	if de.msg == cause {
+		return ""
+	}
+	return strings.TrimSuffix(de.msg, ": "+cause)
+}
+
+func isPublicKey(key FieldKey) bool {
+	k, ok := key.(interface{ IsPublic() bool })
+	return ok && k.IsPublic()
+}
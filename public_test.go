@@ -0,0 +1,175 @@
+package errdef_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/shiwano/errdef"
+)
+
+func TestPublicView(t *testing.T) {
+	orderID, _ := errdef.DefineField[string]("order_id", errdef.PublicField())
+	internalQuery, _ := errdef.DefineField[string]("query")
+	token, _ := errdef.DefineField[string]("token", errdef.PublicField(), errdef.Sensitive())
+
+	notFound := errdef.Define("not_found", errdef.HTTPStatus(404), errdef.Public())
+	database := errdef.Define("database", errdef.HTTPStatus(500))
+
+	t.Run("nearest public error", func(t *testing.T) {
+		cause := notFound.With(t.Context(), orderID("o-1"), internalQuery("SELECT 1")).New("order not found")
+		err := database.With(t.Context(), errdef.TraceID("trace-1"), internalQuery("SELECT 2")).Wrapf(cause, "query failed")
+
+		view, ok := errdef.PublicView(err)
+		if !ok {
+			t.Fatal("want public error to be found")
+		}
+		want := &errdef.PublicErrorView{
+			Message:    "order not found",
+			Kind:       "not_found",
+			HTTPStatus: 404,
+			Fields: map[string]any{
+				"order_id": "o-1",
+				"trace_id": "trace-1",
+			},
+		}
+		if !reflect.DeepEqual(view, want) {
+			t.Errorf("want %+v, got %+v", want, view)
+		}
+	})
+
+	t.Run("through joined and std errors", func(t *testing.T) {
+		cause := notFound.New("order not found")
+		err := fmt.Errorf("handler: %w", errors.Join(errors.New("internal"), cause))
+
+		view, ok := errdef.PublicView(err)
+		if !ok {
+			t.Fatal("want public error to be found")
+		}
+		if view.Kind != "not_found" || view.Message != "order not found" {
+			t.Errorf("unexpected view: %+v", view)
+		}
+	})
+
+	t.Run("sensitive public field", func(t *testing.T) {
+		err := notFound.With(t.Context(), token("secret")).New("order not found")
+
+		view, _ := errdef.PublicView(err)
+		if got := fmt.Sprint(view.Fields["token"]); got != "[REDACTED]" {
+			t.Errorf("want [REDACTED], got %q", got)
+		}
+	})
+
+	t.Run("scrubbed message", func(t *testing.T) {
+		def := errdef.Define("scrubbed", errdef.Public(), errdef.MessageScrubber(errdef.ScrubEmails()))
+		err := def.New("user alice@example.com not found")
+
+		view, _ := errdef.PublicView(err)
+		if want := "user [REDACTED] not found"; view.Message != want {
+			t.Errorf("want %q, got %q", want, view.Message)
+		}
+	})
+
+	t.Run("no public error", func(t *testing.T) {
+		err := database.With(t.Context(), errdef.TraceID("trace-1")).New("connection refused")
+
+		view, ok := errdef.PublicView(err)
+		if ok {
+			t.Fatal("want no public error")
+		}
+		want := &errdef.PublicErrorView{
+			HTTPStatus: 500,
+			Fields:     map[string]any{"trace_id": "trace-1"},
+		}
+		if !reflect.DeepEqual(view, want) {
+			t.Errorf("want %+v, got %+v", want, view)
+		}
+	})
+
+	t.Run("public error wrapping internal error", func(t *testing.T) {
+		invalid := errdef.Define("invalid_user", errdef.HTTPStatus(400), errdef.Public())
+		dbErr := errors.New(`pq: duplicate key value violates unique constraint "users_email_key": alice@example.com`)
+
+		tests := []struct {
+			name string
+			err  error
+			want string
+		}{
+			{"Wrapf", invalid.Wrapf(dbErr, "cannot create user"), "cannot create user"},
+			{"Wrapf with colon", invalid.Wrapf(dbErr, "cannot create user: %s", "bob"), "cannot create user: bob"},
+			{"Wrap", invalid.Wrap(dbErr), ""},
+			{"Join", invalid.Join(dbErr, errors.New("other")), ""},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				view, ok := errdef.PublicView(tt.err)
+				if !ok {
+					t.Fatal("want public error to be found")
+				}
+				if view.Message != tt.want {
+					t.Errorf("want %q, got %q", tt.want, view.Message)
+				}
+			})
+		}
+	})
+
+	t.Run("nil and non-errdef errors", func(t *testing.T) {
+		for _, err := range []error{nil, errors.New("plain")} {
+			view, ok := errdef.PublicView(err)
+			if ok || !reflect.DeepEqual(view, &errdef.PublicErrorView{}) {
+				t.Errorf("want empty view and false for %v, got %+v and %v", err, view, ok)
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		err := notFound.With(t.Context(), orderID("o-1")).New("order not found")
+
+		view, _ := errdef.PublicView(err)
+		got, err := json.Marshal(view)
+		if err != nil {
+			t.Fatalf("want no error, got %v", err)
+		}
+		want := `{"message":"order not found","kind":"not_found","fields":{"order_id":"o-1"}}`
+		if string(got) != want {
+			t.Errorf("want %s, got %s", want, got)
+		}
+	})
+}
+
+func TestPublicErrorView_ProblemDetails(t *testing.T) {
+	def := errdef.Define("not_found", errdef.HTTPStatus(404), errdef.Public())
+	err := def.With(t.Context(), errdef.HelpURL("https://example.com/errors/not_found"), errdef.TraceID("trace-1")).New("order not found")
+
+	view, _ := errdef.PublicView(err)
+	got, err := json.Marshal(view.ProblemDetails())
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+	want := `{"detail":"order not found","kind":"not_found","status":404,"title":"not_found","trace_id":"trace-1","type":"https://example.com/errors/not_found"}`
+	if string(got) != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestPublicErrorView_GRPCCode(t *testing.T) {
+	tests := []struct {
+		status int
+		want   uint32
+	}{
+		{400, 3},
+		{404, 5},
+		{429, 8},
+		{500, 13},
+		{0, 2},
+		{418, 2},
+	}
+	for _, tt := range tests {
+		view := &errdef.PublicErrorView{HTTPStatus: tt.status}
+		if got := view.GRPCCode(); got != tt.want {
+			t.Errorf("status %d: want %d, got %d", tt.status, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package errdef
+
+import (
+	"context"
+	"sync"
+)
+
+// Group is a collection of goroutines working on subtasks that are part of
+// the same overall task. It is similar to errgroup.Group, but any panic that
+// occurs within a goroutine is converted into an error using the Factory
+// instead of crashing the process.
+//
+// Unlike errgroup.Group, Wait returns all failures joined by Factory.Join,
+// not just the first one. A Group must be created with NewGroup.
+type Group struct {
+	factory Factory
+	cancel  context.CancelCauseFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	errs    []error
+}
+
+// RecoverValue executes the given function and recovers from any panic that occurs within it.
+// If a panic occurs, it wraps the panic as an error using the Factory and returns it
+// along with the zero value of T.
+// If no panic occurs, it returns the function's return values as is.
+// The resulting error implements PanicError interface to preserve the original panic value.
+func RecoverValue[T any](f Factory, fn func() (T, error)) (T, error) {
+	var value T
+	err := f.Recover(func() error {
+		v, err := fn()
+		value = v
+		return err
+	})
+	return value, err
+}
+
+// Go runs the given function in a new goroutine and returns a channel that
+// delivers its result. Any panic that occurs within the function is recovered
+// and converted into an error using the Factory.
+//
+// The returned channel receives the error if the function fails or panics,
+// and is closed when the goroutine finishes. Receiving from it therefore
+// yields nil when the function succeeds. If the function calls runtime.Goexit,
+// the channel receives an error wrapping ErrGoexit.
+//
+// Panics that Recover re-raises (http.ErrAbortHandler and the values matched
+// by RepanicWhen) are also delivered as errors wrapping a PanicError, since
+// re-raising them in a new goroutine would crash the process.
+func Go(ctx context.Context, f Factory, fn func(ctx context.Context) error) <-chan error {
+	ch := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		recoverGoroutine(f, func() error { return fn(ctx) }, func(err error) {
+			ch <- err
+		})
+	}()
+	return ch
+}
+
+// NewGroup returns a new Group and an associated context derived from ctx.
+//
+// The derived context is canceled the first time a function passed to Go
+// returns a non-nil error or panics, or the first time Wait returns,
+// whichever occurs first.
+func NewGroup(ctx context.Context, f Factory) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{factory: f, cancel: cancel}, ctx
+}
+
+// Go calls the given function in a new goroutine.
+// Any panic that occurs within the function is recovered and converted into
+// an error using the Group's Factory, including the panics that Recover
+// re-raises, as in the Go function. A call to runtime.Goexit is reported
+// as an error wrapping ErrGoexit.
+func (g *Group) Go(fn func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		recoverGoroutine(g.factory, fn, func(err error) {
+			g.mu.Lock()
+			g.errs = append(g.errs, err)
+			g.mu.Unlock()
+			g.cancel(err)
+		})
+	}()
+}
+
+// Wait blocks until all function calls from the Go method have returned,
+// then returns all failures joined by the Group's Factory.
+// Returns nil if no function failed.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(nil)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.errs) == 0 {
+		return nil
+	}
+	return g.factory.Join(g.errs...)
+}
+
+// recoverGoroutine runs fn with f.Recover and passes any resulting error to report.
+// If fn calls runtime.Goexit, report receives an error wrapping ErrGoexit
+// before the goroutine exits. Panics re-raised by the Recover policy are
+// reported as errors wrapping a PanicError, in the same format as Recover.
+func recoverGoroutine(f Factory, fn func() error, report func(error)) {
+	normalReturn := false
+	defer func() {
+		if normalReturn {
+			return
+		}
+		if panicValue := recover(); panicValue != nil {
+			report(f.Wrapf(newPanicError(panicValue), "panic"))
+			return
+		}
+		report(f.Wrap(ErrGoexit))
+	}()
+	err := f.Recover(fn)
+	normalReturn = true
+	if err != nil {
+		report(err)
+	}
+}
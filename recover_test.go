@@ -0,0 +1,267 @@
+package errdef_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"testing"
+
+	"github.com/shiwano/errdef"
+)
+
+func TestRecoverValue(t *testing.T) {
+	t.Run("no panic", func(t *testing.T) {
+		def := errdef.Define("panic_error")
+		got, err := errdef.RecoverValue(def, func() (int, error) {
+			return 42, nil
+		})
+
+		if err != nil {
+			t.Fatalf("want no error, got %v", err)
+		}
+		if got != 42 {
+			t.Errorf("want value %d, got %d", 42, got)
+		}
+	})
+
+	t.Run("return error without panic", func(t *testing.T) {
+		def := errdef.Define("panic_error")
+		returnedErr := errors.New("normal error")
+		got, err := errdef.RecoverValue(def, func() (string, error) {
+			return "partial", returnedErr
+		})
+
+		if err != returnedErr {
+			t.Errorf("want returned error %v, got %v", returnedErr, err)
+		}
+		if got != "partial" {
+			t.Errorf("want value %q, got %q", "partial", got)
+		}
+	})
+
+	t.Run("recover panic", func(t *testing.T) {
+		def := errdef.Define("panic_error")
+		got, err := errdef.RecoverValue(def, func() (int, error) {
+			panic("test panic")
+		})
+
+		if !errors.Is(err, def) {
+			t.Fatal("want error to be wrapped by the definition")
+		}
+		if got != 0 {
+			t.Errorf("want zero value, got %d", got)
+		}
+
+		var panicErr errdef.PanicError
+		if !errors.As(err, &panicErr) {
+			t.Fatal("want error to be a PanicError")
+		}
+		if panicErr.PanicValue() != "test panic" {
+			t.Errorf("want panic value %q, got %v", "test panic", panicErr.PanicValue())
+		}
+	})
+}
+
+func TestGo(t *testing.T) {
+	t.Run("no error", func(t *testing.T) {
+		def := errdef.Define("panic_error")
+		err := <-errdef.Go(context.Background(), def, func(ctx context.Context) error {
+			return nil
+		})
+
+		if err != nil {
+			t.Errorf("want no error, got %v", err)
+		}
+	})
+
+	t.Run("return error", func(t *testing.T) {
+		def := errdef.Define("panic_error")
+		returnedErr := errors.New("normal error")
+		err := <-errdef.Go(context.Background(), def, func(ctx context.Context) error {
+			return returnedErr
+		})
+
+		if err != returnedErr {
+			t.Errorf("want returned error %v, got %v", returnedErr, err)
+		}
+	})
+
+	t.Run("recover panic", func(t *testing.T) {
+		def := errdef.Define("panic_error")
+		ch := errdef.Go(context.Background(), def, func(ctx context.Context) error {
+			panic("goroutine panic")
+		})
+
+		err := <-ch
+		if !errors.Is(err, def) {
+			t.Fatalf("want error to be wrapped by the definition, got %v", err)
+		}
+		if _, ok := <-ch; ok {
+			t.Error("want channel to be closed")
+		}
+	})
+
+	t.Run("reports panics re-raised by Recover", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			def        errdef.Definition
+			panicValue any
+		}{
+			{"http.ErrAbortHandler", errdef.Define("panic_error"), http.ErrAbortHandler},
+			{"RepanicWhen", errdef.Define("panic_error", errdef.RepanicWhen(func(v any) bool { return v == "fatal" })), "fatal"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := <-errdef.Go(context.Background(), tt.def, func(ctx context.Context) error {
+					panic(tt.panicValue)
+				})
+
+				if !errors.Is(err, tt.def) {
+					t.Errorf("want error to be wrapped by the definition, got %v", err)
+				}
+				var pe errdef.PanicError
+				if !errors.As(err, &pe) || pe.PanicValue() != tt.panicValue {
+					t.Errorf("want panic value %v, got %v", tt.panicValue, err)
+				}
+				if want := fmt.Sprintf("panic: %v", tt.panicValue); err.Error() != want {
+					t.Errorf("want message %q, got %q", want, err.Error())
+				}
+			})
+		}
+	})
+
+	t.Run("reports runtime.Goexit", func(t *testing.T) {
+		def := errdef.Define("panic_error")
+		err := <-errdef.Go(context.Background(), def, func(ctx context.Context) error {
+			runtime.Goexit()
+			return nil
+		})
+
+		if !errors.Is(err, def) {
+			t.Errorf("want error to be wrapped by the definition, got %v", err)
+		}
+		if !errors.Is(err, errdef.ErrGoexit) {
+			t.Errorf("want ErrGoexit, got %v", err)
+		}
+	})
+
+	t.Run("passes context", func(t *testing.T) {
+		type ctxKey struct{}
+		def := errdef.Define("panic_error")
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+		var got any
+		<-errdef.Go(ctx, def, func(ctx context.Context) error {
+			got = ctx.Value(ctxKey{})
+			return nil
+		})
+
+		if got != "value" {
+			t.Errorf("want context value %q, got %v", "value", got)
+		}
+	})
+}
+
+func TestGroup(t *testing.T) {
+	t.Run("no error", func(t *testing.T) {
+		def := errdef.Define("group_error")
+		g, _ := errdef.NewGroup(context.Background(), def)
+		for range 3 {
+			g.Go(func() error { return nil })
+		}
+
+		if err := g.Wait(); err != nil {
+			t.Errorf("want no error, got %v", err)
+		}
+	})
+
+	t.Run("joins all failures", func(t *testing.T) {
+		def := errdef.Define("group_error")
+		panicDef := errdef.Define("panic_error")
+		err1 := errors.New("error 1")
+		err2 := errors.New("error 2")
+
+		g, _ := errdef.NewGroup(context.Background(), def)
+		g.Go(func() error { return err1 })
+		g.Go(func() error { return err2 })
+		g.Go(func() error { return nil })
+		g.Go(func() error { return panicDef.Recover(func() error { panic("boom") }) })
+		g.Go(func() error { panic("group panic") })
+
+		err := g.Wait()
+		if !errors.Is(err, def) {
+			t.Fatalf("want error to be wrapped by the definition, got %v", err)
+		}
+		if !errors.Is(err, err1) || !errors.Is(err, err2) {
+			t.Error("want error to contain all returned errors")
+		}
+		if !errors.Is(err, panicDef) {
+			t.Error("want error to contain the inner panic error")
+		}
+		if got := len(err.(errdef.Error).Unwrap()); got != 4 {
+			t.Errorf("want %d causes, got %d", 4, got)
+		}
+
+		var panicErr errdef.PanicError
+		if !errors.As(err, &panicErr) {
+			t.Error("want error to contain a PanicError")
+		}
+	})
+
+	t.Run("cancels context on first failure", func(t *testing.T) {
+		def := errdef.Define("group_error")
+		returnedErr := errors.New("normal error")
+
+		g, ctx := errdef.NewGroup(context.Background(), def)
+		g.Go(func() error { return returnedErr })
+		g.Go(func() error {
+			<-ctx.Done()
+			return nil
+		})
+
+		if err := g.Wait(); !errors.Is(err, returnedErr) {
+			t.Errorf("want returned error, got %v", err)
+		}
+		if got := context.Cause(ctx); got != returnedErr {
+			t.Errorf("want context cause %v, got %v", returnedErr, got)
+		}
+	})
+
+	t.Run("reports runtime.Goexit", func(t *testing.T) {
+		def := errdef.Define("group_error")
+		g, _ := errdef.NewGroup(context.Background(), def)
+		g.Go(func() error {
+			runtime.Goexit()
+			return nil
+		})
+
+		if err := g.Wait(); !errors.Is(err, errdef.ErrGoexit) {
+			t.Errorf("want ErrGoexit, got %v", err)
+		}
+	})
+
+	t.Run("reports http.ErrAbortHandler", func(t *testing.T) {
+		def := errdef.Define("group_error")
+		g, _ := errdef.NewGroup(context.Background(), def)
+		g.Go(func() error {
+			panic(http.ErrAbortHandler)
+		})
+
+		var pe errdef.PanicError
+		if err := g.Wait(); !errors.As(err, &pe) || pe.PanicValue() != http.ErrAbortHandler {
+			t.Errorf("want http.ErrAbortHandler panic, got %v", err)
+		}
+	})
+
+	t.Run("cancels context on wait", func(t *testing.T) {
+		def := errdef.Define("group_error")
+		g, ctx := errdef.NewGroup(context.Background(), def)
+		_ = g.Wait()
+
+		if ctx.Err() == nil {
+			t.Error("want context to be canceled")
+		}
+	})
+}
@@ -1,14 +1,29 @@
 package errdef
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
+	"sync/atomic"
 )
 
-const redactedStr = "[REDACTED]"
+const (
+	redactedStr      = "[REDACTED]"
+	maskStr          = "****"
+	hashedPrefix     = "[HASHED:"
+	hashedSuffix     = "]"
+	hashedDigestSize = 8
+)
+
+var (
+	unsafeMode atomic.Bool
+	hashKey    atomic.Pointer[[]byte]
+)
 
 // Redacted[T] wraps a value so it always renders as "[REDACTED]"
 // when printed, marshaled, or logged (fmt, json, slog),
@@ -81,3 +96,200 @@ func (r Redacted[T]) LogValue() slog.Value {
 func Redact[T any](value T) Redacted[T] {
 	return Redacted[T]{value: value}
 }
+
+// Masked[T] wraps a value so it renders as a partial view such as "****1234"
+// when printed, marshaled, or logged (fmt, json, slog),
+// while still allowing access to the original value via Value().
+// Use this when a hint of the value is needed, e.g. the last digits of a card number.
+type Masked[T any] struct {
+	value   T
+	visible int
+}
+
+var (
+	_ fmt.Stringer             = Masked[any]{}
+	_ fmt.GoStringer           = Masked[any]{}
+	_ fmt.Formatter            = Masked[any]{}
+	_ json.Marshaler           = Masked[any]{}
+	_ encoding.TextMarshaler   = Masked[any]{}
+	_ encoding.BinaryMarshaler = Masked[any]{}
+	_ slog.LogValuer           = Masked[any]{}
+)
+
+// Value returns the original wrapped value.
+func (m Masked[T]) Value() T {
+	return m.value
+}
+
+// IsRedacted returns true. This method allows identifying Masked[T] values
+// via interface without knowing the type parameter T.
+func (m Masked[T]) IsRedacted() bool {
+	return true
+}
+
+// String implements fmt.Stringer, returning "****" followed by the last
+// visible characters of the value. If the value is not longer than the
+// visible characters, only "****" is returned.
+func (m Masked[T]) String() string {
+	runes := []rune(fmt.Sprint(m.value))
+	if m.visible <= 0 || len(runes) <= m.visible {
+		return maskStr
+	}
+	return maskStr + string(runes[len(runes)-m.visible:])
+}
+
+// GoString implements fmt.GoStringer, returning the masked value for %#v format.
+func (m Masked[T]) GoString() string {
+	return m.String()
+}
+
+// Format implements fmt.Formatter, always rendering the masked value regardless of the format verb.
+func (m Masked[T]) Format(s fmt.State, verb rune) {
+	_, _ = io.WriteString(s, m.String())
+}
+
+// MarshalJSON implements json.Marshaler, marshaling the masked value.
+func (m Masked[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the masked value as text.
+func (m Masked[T]) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, returning the masked value as bytes.
+func (m Masked[T]) MarshalBinary() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// LogValue implements slog.LogValuer, logging the masked value.
+func (m Masked[T]) LogValue() slog.Value {
+	return slog.StringValue(m.String())
+}
+
+// Mask wraps value in a Masked[T], which renders as "****" followed by
+// the last visible characters of the value (e.g., "****1234")
+// when printed, marshaled, or logged (fmt, json, slog),
+// while still allowing access to the original value via Value().
+func Mask[T any](value T, visible int) Masked[T] {
+	return Masked[T]{value: value, visible: visible}
+}
+
+// Hashed[T] wraps a value so it renders as a keyed HMAC-SHA256 digest such as
+// "[HASHED:3f2a9c0d1e4b5a6f]" when printed, marshaled, or logged (fmt, json, slog),
+// while still allowing access to the original value via Value().
+// The same value always renders as the same digest for the same key,
+// so it can be correlated across logs without being revealed.
+// The key is set globally by SetHashKey.
+type Hashed[T any] struct {
+	value T
+}
+
+var (
+	_ fmt.Stringer             = Hashed[any]{}
+	_ fmt.GoStringer           = Hashed[any]{}
+	_ fmt.Formatter            = Hashed[any]{}
+	_ json.Marshaler           = Hashed[any]{}
+	_ encoding.TextMarshaler   = Hashed[any]{}
+	_ encoding.BinaryMarshaler = Hashed[any]{}
+	_ slog.LogValuer           = Hashed[any]{}
+)
+
+// Value returns the original wrapped value.
+func (h Hashed[T]) Value() T {
+	return h.value
+}
+
+// IsRedacted returns true. This method allows identifying Hashed[T] values
+// via interface without knowing the type parameter T.
+func (h Hashed[T]) IsRedacted() bool {
+	return true
+}
+
+// String implements fmt.Stringer, returning the keyed digest of the value.
+func (h Hashed[T]) String() string {
+	var key []byte
+	if k := hashKey.Load(); k != nil {
+		key = *k
+	}
+	mac := hmac.New(sha256.New, key)
+	_, _ = io.WriteString(mac, fmt.Sprint(h.value))
+	return hashedPrefix + hex.EncodeToString(mac.Sum(nil)[:hashedDigestSize]) + hashedSuffix
+}
+
+// GoString implements fmt.GoStringer, returning the digest for %#v format.
+func (h Hashed[T]) GoString() string {
+	return h.String()
+}
+
+// Format implements fmt.Formatter, always rendering the digest regardless of the format verb.
+func (h Hashed[T]) Format(s fmt.State, verb rune) {
+	_, _ = io.WriteString(s, h.String())
+}
+
+// MarshalJSON implements json.Marshaler, marshaling the digest.
+func (h Hashed[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.String())
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the digest as text.
+func (h Hashed[T]) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, returning the digest as bytes.
+func (h Hashed[T]) MarshalBinary() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// LogValue implements slog.LogValuer, logging the digest.
+func (h Hashed[T]) LogValue() slog.Value {
+	return slog.StringValue(h.String())
+}
+
+// Hash wraps value in a Hashed[T], which renders as a keyed HMAC-SHA256 digest
+// when printed, marshaled, or logged (fmt, json, slog),
+// while still allowing access to the original value via Value().
+func Hash[T any](value T) Hashed[T] {
+	return Hashed[T]{value: value}
+}
+
+// SetHashKey sets the secret key used by Hashed[T] to compute digests.
+// Use the same key across services to correlate values in their logs.
+// Without a key, digests are computed with an empty key and can be brute-forced.
+func SetHashKey(key []byte) {
+	k := append([]byte(nil), key...)
+	hashKey.Store(&k)
+}
+
+// SetUnsafeMode enables or disables the unsafe presenter mode globally.
+// In unsafe mode, values of fields declared with Sensitive are rendered as is
+// instead of "[REDACTED]". This is intended for local debugging only and
+// must never be enabled in production.
+//
+// NOTE: Redacted[T] values are always rendered as "[REDACTED]" regardless of this mode.
+func SetUnsafeMode(enabled bool) {
+	unsafeMode.Store(enabled)
+}
+
+// IsUnsafeMode reports whether the unsafe presenter mode is enabled.
+func IsUnsafeMode() bool {
+	return unsafeMode.Load()
+}
+
+// PresentFieldValue returns the value of a field as presenters should render it.
+// It returns "[REDACTED]" if the key was declared with the Sensitive option
+// and unsafe mode is disabled; otherwise it returns value.Value().
+// Use this in custom formatters, marshalers, and logging integrations.
+func PresentFieldValue(key FieldKey, value FieldValue) any {
+	if isSensitiveKey(key) && !unsafeMode.Load() {
+		return redactedStr
+	}
+	return value.Value()
+}
+
+func isSensitiveKey(key FieldKey) bool {
+	k, ok := key.(interface{ IsSensitive() bool })
+	return ok && k.IsSensitive()
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"log/slog"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -175,3 +176,217 @@ func TestRedacted_LogValue(t *testing.T) {
 		}
 	})
 }
+
+func TestSensitive(t *testing.T) {
+	ctor, extr := DefineField[string]("token", Sensitive())
+	def := Define("auth_error", ctor("my-secret-token"))
+	err := def.New("authentication failed")
+
+	t.Run("extractor returns raw value", func(t *testing.T) {
+		got, ok := extr(err)
+		if !ok {
+			t.Fatal("want field to be found")
+		}
+		if got != "my-secret-token" {
+			t.Errorf("want %q, got %q", "my-secret-token", got)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(err)
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		if strings.Contains(string(data), "my-secret-token") {
+			t.Error("want secret to be redacted, but found in JSON output")
+		}
+		if !strings.Contains(string(data), `"token":"[REDACTED]"`) {
+			t.Errorf("want [REDACTED] in JSON output, got %s", data)
+		}
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		logger.Error("error occurred", "error", err)
+
+		output := buf.String()
+		if strings.Contains(output, "my-secret-token") {
+			t.Error("want secret to be redacted, but found in log output")
+		}
+		if !strings.Contains(output, `"token":"[REDACTED]"`) {
+			t.Errorf("want [REDACTED] in log output, got %s", output)
+		}
+	})
+
+	t.Run("format", func(t *testing.T) {
+		output := fmt.Sprintf("%+v", err)
+		if strings.Contains(output, "my-secret-token") {
+			t.Error("want secret to be redacted, but found in formatted output")
+		}
+		if !strings.Contains(output, "token: [REDACTED]") {
+			t.Errorf("want [REDACTED] in formatted output, got %s", output)
+		}
+	})
+}
+
+func TestSetUnsafeMode(t *testing.T) {
+	ctor, _ := DefineField[string]("token", Sensitive())
+	err := Define("auth_error", ctor("my-secret-token")).New("authentication failed")
+
+	SetUnsafeMode(true)
+	t.Cleanup(func() { SetUnsafeMode(false) })
+
+	if !IsUnsafeMode() {
+		t.Fatal("want unsafe mode to be enabled")
+	}
+
+	data, _ := json.Marshal(err)
+	if !strings.Contains(string(data), `"token":"my-secret-token"`) {
+		t.Errorf("want raw value in JSON output, got %s", data)
+	}
+	if output := fmt.Sprintf("%+v", err); !strings.Contains(output, "token: my-secret-token") {
+		t.Errorf("want raw value in formatted output, got %s", output)
+	}
+
+	SetUnsafeMode(false)
+	data, _ = json.Marshal(err)
+	if !strings.Contains(string(data), `"token":"[REDACTED]"`) {
+		t.Errorf("want [REDACTED] in JSON output, got %s", data)
+	}
+}
+
+func TestPresentFieldValue(t *testing.T) {
+	sensitiveCtor, _ := DefineField[string]("token", Sensitive())
+	plainCtor, _ := DefineField[string]("user_id")
+	def := Define("test_error", sensitiveCtor("secret"), plainCtor("u123"))
+
+	for k, v := range def.Fields().All() {
+		got := PresentFieldValue(k, v)
+		switch k.String() {
+		case "token":
+			if got != "[REDACTED]" {
+				t.Errorf("want [REDACTED] for sensitive field, got %v", got)
+			}
+		case "user_id":
+			if got != "u123" {
+				t.Errorf("want raw value for plain field, got %v", got)
+			}
+		}
+	}
+}
+
+func TestMasked(t *testing.T) {
+	t.Run("shows last characters", func(t *testing.T) {
+		masked := Mask("4111111111111234", 4)
+
+		if got := masked.String(); got != "****1234" {
+			t.Errorf("want %q, got %q", "****1234", got)
+		}
+		if masked.Value() != "4111111111111234" {
+			t.Errorf("want original value, got %v", masked.Value())
+		}
+	})
+
+	t.Run("short value is fully masked", func(t *testing.T) {
+		if got := Mask("1234", 4).String(); got != "****" {
+			t.Errorf("want %q, got %q", "****", got)
+		}
+		if got := Mask("secret", 0).String(); got != "****" {
+			t.Errorf("want %q, got %q", "****", got)
+		}
+	})
+
+	t.Run("non string value", func(t *testing.T) {
+		if got := Mask(123456789, 3).String(); got != "****789" {
+			t.Errorf("want %q, got %q", "****789", got)
+		}
+	})
+
+	t.Run("presenters", func(t *testing.T) {
+		masked := Mask("4111111111111234", 4)
+
+		for _, format := range []string{"%s", "%v", "%+v", "%#v"} {
+			if got := fmt.Sprintf(format, masked); got != "****1234" {
+				t.Errorf("%s: want %q, got %q", format, "****1234", got)
+			}
+		}
+		if data, _ := json.Marshal(masked); string(data) != `"****1234"` {
+			t.Errorf("want JSON %q, got %s", `"****1234"`, data)
+		}
+		if text, _ := masked.MarshalText(); string(text) != "****1234" {
+			t.Errorf("want text %q, got %s", "****1234", text)
+		}
+		if bin, _ := masked.MarshalBinary(); string(bin) != "****1234" {
+			t.Errorf("want binary %q, got %s", "****1234", bin)
+		}
+		if got := masked.LogValue().String(); got != "****1234" {
+			t.Errorf("want log value %q, got %q", "****1234", got)
+		}
+	})
+}
+
+func TestHashed(t *testing.T) {
+	SetHashKey([]byte("test-key"))
+	t.Cleanup(func() { SetHashKey(nil) })
+
+	t.Run("same value correlates", func(t *testing.T) {
+		h1 := Hash("u123")
+		h2 := Hash("u123")
+		h3 := Hash("u456")
+
+		if h1.String() != h2.String() {
+			t.Errorf("want same digest for same value, got %q and %q", h1, h2)
+		}
+		if h1.String() == h3.String() {
+			t.Errorf("want different digests for different values, got %q", h1)
+		}
+		if h1.Value() != "u123" {
+			t.Errorf("want original value, got %v", h1.Value())
+		}
+	})
+
+	t.Run("format", func(t *testing.T) {
+		got := Hash("u123").String()
+		if !regexp.MustCompile(`^\[HASHED:[0-9a-f]{16}\]$`).MatchString(got) {
+			t.Errorf("want hashed form, got %q", got)
+		}
+		if strings.Contains(got, "u123") {
+			t.Errorf("want value not to be revealed, got %q", got)
+		}
+	})
+
+	t.Run("depends on key", func(t *testing.T) {
+		before := Hash("u123").String()
+		SetHashKey([]byte("other-key"))
+		after := Hash("u123").String()
+		SetHashKey([]byte("test-key"))
+
+		if before == after {
+			t.Error("want digest to depend on the key")
+		}
+	})
+
+	t.Run("presenters", func(t *testing.T) {
+		hashed := Hash("u123")
+		want := hashed.String()
+
+		for _, format := range []string{"%s", "%v", "%+v", "%#v"} {
+			if got := fmt.Sprintf(format, hashed); got != want {
+				t.Errorf("%s: want %q, got %q", format, want, got)
+			}
+		}
+		if data, _ := json.Marshal(hashed); string(data) != `"`+want+`"` {
+			t.Errorf("want JSON %q, got %s", want, data)
+		}
+		if text, _ := hashed.MarshalText(); string(text) != want {
+			t.Errorf("want text %q, got %s", want, text)
+		}
+		if bin, _ := hashed.MarshalBinary(); string(bin) != want {
+			t.Errorf("want binary %q, got %s", want, bin)
+		}
+		if got := hashed.LogValue().String(); got != want {
+			t.Errorf("want log value %q, got %q", want, got)
+		}
+	})
+}
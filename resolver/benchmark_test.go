@@ -1,6 +1,7 @@
 package resolver_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/shiwano/errdef"
@@ -63,3 +64,66 @@ func BenchmarkDefaultResolverResolveKindOrDefault(b *testing.B) {
 		_ = r.ResolveKindOrDefault("not_found")
 	}
 }
+
+func benchDefs1k() []errdef.Definition {
+	defs := make([]errdef.Definition, 1000)
+	for i := range defs {
+		defs[i] = errdef.Define(errdef.Kind(fmt.Sprintf("error_%d", i)), errdef.HTTPStatus(1000+i))
+	}
+	return defs
+}
+
+// resolver: ResolveKind with 1k definitions
+func BenchmarkResolverResolveKind1k(b *testing.B) {
+	r := resolver.New(benchDefs1k()...)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = r.ResolveKind("error_999")
+	}
+}
+
+// resolver: ResolveField with 1k definitions (first match)
+func BenchmarkResolverResolveField1kFirst(b *testing.B) {
+	r := resolver.New(benchDefs1k()...)
+	key := errdef.HTTPStatus.Key()
+	b.ResetTimer()
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = r.ResolveField(key, 1000)
+	}
+}
+
+// resolver: ResolveField with 1k definitions (last match)
+func BenchmarkResolverResolveField1kLast(b *testing.B) {
+	r := resolver.New(benchDefs1k()...)
+	key := errdef.HTTPStatus.Key()
+	b.ResetTimer()
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = r.ResolveField(key, 1999)
+	}
+}
+
+// resolver: ResolveField with 1k definitions (not found)
+func BenchmarkResolverResolveField1kNotFound(b *testing.B) {
+	r := resolver.New(benchDefs1k()...)
+	key := errdef.HTTPStatus.Key()
+	b.ResetTimer()
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = r.ResolveField(key, 999)
+	}
+}
+
+// resolver: ResolveFieldFunc with 1k definitions (last match)
+func BenchmarkResolverResolveFieldFunc1kLast(b *testing.B) {
+	r := resolver.New(benchDefs1k()...)
+	key := errdef.HTTPStatus.Key()
+	eq := func(v errdef.FieldValue) bool { return v.Equal(1999) }
+	b.ResetTimer()
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = r.ResolveFieldFunc(key, eq)
+	}
+}
@@ -0,0 +1,57 @@
+package resolver
+
+import (
+	"slices"
+
+	"github.com/shiwano/errdef"
+)
+
+// ChainResolver asks multiple resolvers in order and returns the first
+// resolution, e.g., to fall back from the definitions of a service to those
+// of the shared libraries.
+type ChainResolver struct {
+	resolvers []Resolver
+}
+
+var _ Resolver = (*ChainResolver)(nil)
+
+// Chain creates a new ChainResolver that asks the given resolvers in order.
+func Chain(resolvers ...Resolver) *ChainResolver {
+	return &ChainResolver{resolvers: slices.Clone(resolvers)}
+}
+
+// WithDefault creates a new DefaultResolver that uses the given definition
+// as a default when resolution fails.
+func (r *ChainResolver) WithDefault(defaultDef errdef.Definition) *DefaultResolver {
+	return WithDefault(r, defaultDef)
+}
+
+// ResolveKind implements Resolver.
+func (r *ChainResolver) ResolveKind(kind errdef.Kind) (errdef.Definition, bool) {
+	for _, res := range r.resolvers {
+		if def, ok := res.ResolveKind(kind); ok {
+			return def, true
+		}
+	}
+	return nil, false
+}
+
+// ResolveField implements Resolver.
+func (r *ChainResolver) ResolveField(key errdef.FieldKey, want any) (errdef.Definition, bool) {
+	for _, res := range r.resolvers {
+		if def, ok := res.ResolveField(key, want); ok {
+			return def, true
+		}
+	}
+	return nil, false
+}
+
+// ResolveFieldFunc implements Resolver.
+func (r *ChainResolver) ResolveFieldFunc(key errdef.FieldKey, eq func(v errdef.FieldValue) bool) (errdef.Definition, bool) {
+	for _, res := range r.resolvers {
+		if def, ok := res.ResolveFieldFunc(key, eq); ok {
+			return def, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,75 @@
+package resolver_test
+
+import (
+	"testing"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/resolver"
+)
+
+func TestChain(t *testing.T) {
+	svcNotFound := errdef.Define("not_found", errdef.HTTPStatus(404))
+	svcQuota := errdef.Define("quota_exceeded", errdef.HTTPStatus(429))
+	libNotFound := errdef.Define("not_found", errdef.HTTPStatus(404))
+	libTimeout := errdef.Define("timeout", errdef.HTTPStatus(504))
+
+	r := resolver.Chain(resolver.New(svcNotFound, svcQuota), resolver.New(libNotFound, libTimeout))
+
+	t.Run("ResolveKind", func(t *testing.T) {
+		tests := []struct {
+			kind errdef.Kind
+			want errdef.Definition
+		}{
+			{"not_found", svcNotFound},
+			{"quota_exceeded", svcQuota},
+			{"timeout", libTimeout},
+			{"unknown", nil},
+		}
+		for _, tt := range tests {
+			t.Run(string(tt.kind), func(t *testing.T) {
+				result, ok := r.ResolveKind(tt.kind)
+				if ok != (tt.want != nil) || result != tt.want {
+					t.Errorf("want %v, got %v", tt.want, result)
+				}
+			})
+		}
+	})
+
+	t.Run("ResolveField", func(t *testing.T) {
+		tests := []struct {
+			status int
+			want   errdef.Definition
+		}{
+			{404, svcNotFound},
+			{504, libTimeout},
+			{500, nil},
+		}
+		for _, tt := range tests {
+			result, ok := r.ResolveField(errdef.HTTPStatus.Key(), tt.status)
+			if ok != (tt.want != nil) || result != tt.want {
+				t.Errorf("status %d: want %v, got %v", tt.status, tt.want, result)
+			}
+		}
+	})
+
+	t.Run("ResolveFieldFunc", func(t *testing.T) {
+		result, ok := r.ResolveFieldFunc(errdef.HTTPStatus.Key(), func(v errdef.FieldValue) bool {
+			return v.Value().(int) >= 500
+		})
+		if !ok || result != libTimeout {
+			t.Errorf("want libTimeout, got %v", result)
+		}
+	})
+
+	t.Run("WithDefault", func(t *testing.T) {
+		defaultDef := errdef.Define("default")
+		dr := r.WithDefault(defaultDef)
+
+		if result := dr.ResolveKindOrDefault("timeout"); result != libTimeout {
+			t.Errorf("want libTimeout, got %v", result)
+		}
+		if result := dr.ResolveKindOrDefault("unknown"); result != defaultDef {
+			t.Errorf("want default, got %v", result)
+		}
+	})
+}
@@ -1,22 +1,59 @@
 package resolver
 
-import "github.com/shiwano/errdef"
+import (
+	"maps"
+
+	"github.com/shiwano/errdef"
+)
 
 // DefaultResolver wraps a Resolver with default functionality,
 // returning a default definition when resolution fails.
 type DefaultResolver struct {
-	resolver   Resolver
-	defaultDef errdef.Definition
+	resolver          Resolver
+	defaultDef        errdef.Definition
+	namespaceDefaults map[errdef.Kind]errdef.Definition
 }
 
 var _ Resolver = (*DefaultResolver)(nil)
 
+// WithDefault creates a new DefaultResolver that wraps r, which can be any
+// Resolver (e.g., a ChainResolver), and uses the given definition as a
+// default when resolution fails.
+func WithDefault(r Resolver, defaultDef errdef.Definition) *DefaultResolver {
+	return &DefaultResolver{
+		resolver:   r,
+		defaultDef: defaultDef,
+	}
+}
+
+// WithNamespaceDefault creates a new DefaultResolver that uses def instead of
+// the default definition for the unknown kinds in namespace, so that, e.g.,
+// an unknown "payment.card.expired" degrades to "payment.unknown" for the
+// namespace "payment". If namespaces are nested, the longest one wins.
+func (r *DefaultResolver) WithNamespaceDefault(namespace errdef.Kind, def errdef.Definition) *DefaultResolver {
+	defaults := maps.Clone(r.namespaceDefaults)
+	if defaults == nil {
+		defaults = make(map[errdef.Kind]errdef.Definition, 1)
+	}
+	defaults[namespace] = def
+
+	clone := *r
+	clone.namespaceDefaults = defaults
+	return &clone
+}
+
 // ResolveKindOrDefault resolves a definition by its Kind.
-// Returns the default definition if resolution fails.
+// Returns the default definition of the longest namespace of kind set by
+// WithNamespaceDefault, or the default definition, if resolution fails.
 func (r *DefaultResolver) ResolveKindOrDefault(kind errdef.Kind) errdef.Definition {
 	if def, ok := r.resolver.ResolveKind(kind); ok {
 		return def
 	}
+	for ns, ok := parentNamespace(kind); ok; ns, ok = parentNamespace(ns) {
+		if def, ok := r.namespaceDefaults[ns]; ok {
+			return def
+		}
+	}
 	return r.defaultDef
 }
 
@@ -32,6 +32,59 @@ func TestDefaultResolver_ResolveKindOrDefault(t *testing.T) {
 	})
 }
 
+func TestWithDefault(t *testing.T) {
+	def1 := errdef.Define("error1")
+	def2 := errdef.Define("error2")
+	defaultDef := errdef.Define("default")
+
+	r := resolver.WithDefault(resolver.Chain(resolver.New(def1), resolver.New(def2)), defaultDef)
+
+	if result := r.ResolveKindOrDefault("error2"); result != def2 {
+		t.Errorf("want def2, got %v", result)
+	}
+	if result := r.ResolveKindOrDefault("unknown"); result != defaultDef {
+		t.Errorf("want default, got %v", result)
+	}
+}
+
+func TestDefaultResolver_WithNamespaceDefault(t *testing.T) {
+	declined := errdef.Define("payment.card.declined")
+	paymentUnknown := errdef.Define("payment.unknown")
+	cardUnknown := errdef.Define("payment.card.unknown")
+	defaultDef := errdef.Define("default")
+
+	base := resolver.New(declined, paymentUnknown, cardUnknown).WithDefault(defaultDef)
+	r := base.
+		WithNamespaceDefault("payment", paymentUnknown).
+		WithNamespaceDefault("payment.card", cardUnknown)
+
+	tests := []struct {
+		kind errdef.Kind
+		want errdef.Definition
+	}{
+		{"payment.card.declined", declined},
+		{"payment.card.expired", cardUnknown},
+		{"payment.card.visa.expired", cardUnknown},
+		{"payment.refund.failed", paymentUnknown},
+		{"payment", defaultDef},
+		{"paymentx.failed", defaultDef},
+		{"shipping.lost", defaultDef},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			if result := r.ResolveKindOrDefault(tt.kind); result != tt.want {
+				t.Errorf("want %v, got %v", tt.want.Kind(), result.Kind())
+			}
+		})
+	}
+
+	t.Run("does not modify the original resolver", func(t *testing.T) {
+		if result := base.ResolveKindOrDefault("payment.card.expired"); result != defaultDef {
+			t.Errorf("want default, got %v", result.Kind())
+		}
+	})
+}
+
 func TestDefaultResolver_ResolveFieldOrDefault(t *testing.T) {
 	ctor, _ := errdef.DefineField[string]("test_field")
 
@@ -0,0 +1,119 @@
+package resolver
+
+import (
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/shiwano/errdef"
+)
+
+type (
+	// fieldIndexes lazily builds and caches a fieldIndex per FieldKey.
+	// It is shared by the clones of a StrictResolver, since they have the
+	// same definitions.
+	fieldIndexes struct {
+		defs    []errdef.Definition
+		indexes sync.Map // map[errdef.FieldKey]*fieldIndex
+	}
+
+	// fieldIndex holds the definitions that have a field, in resolution
+	// order, and maps their comparable values to the first definition.
+	fieldIndex struct {
+		entries []fieldEntry
+		byValue map[any]fieldEntry
+		// unindexed are the entries whose values are not in byValue, which
+		// must also be checked to keep "first definition wins".
+		unindexed []fieldEntry
+	}
+
+	fieldEntry struct {
+		pos   int
+		def   errdef.Definition
+		value errdef.FieldValue
+	}
+)
+
+func newFieldIndexes(defs []errdef.Definition) *fieldIndexes {
+	return &fieldIndexes{defs: defs}
+}
+
+func (x *fieldIndexes) get(key errdef.FieldKey) *fieldIndex {
+	if x == nil {
+		return &fieldIndex{} // zero-value StrictResolver
+	}
+	if idx, ok := x.indexes.Load(key); ok {
+		return idx.(*fieldIndex)
+	}
+	idx, _ := x.indexes.LoadOrStore(key, x.build(key))
+	return idx.(*fieldIndex)
+}
+
+func (x *fieldIndexes) build(key errdef.FieldKey) *fieldIndex {
+	idx := &fieldIndex{byValue: make(map[any]fieldEntry)}
+	for _, def := range x.defs {
+		v, ok := def.Fields().Get(key)
+		if !ok {
+			continue
+		}
+		e := fieldEntry{pos: len(idx.entries), def: def, value: v}
+		idx.entries = append(idx.entries, e)
+
+		if raw := v.Value(); isIndexable(raw) && v.Equal(raw) {
+			if _, exists := idx.byValue[raw]; !exists {
+				idx.byValue[raw] = e // First definition wins
+			}
+		} else {
+			idx.unindexed = append(idx.unindexed, e)
+		}
+	}
+	return idx
+}
+
+// lookup returns the first definition whose field value equals want.
+func (idx *fieldIndex) lookup(want any) (errdef.Definition, bool) {
+	if !isIndexable(want) {
+		return idx.find(func(v errdef.FieldValue) bool { return v.Equal(want) })
+	}
+
+	e, found := idx.byValue[want]
+	end := len(idx.entries)
+	if found {
+		end = e.pos
+	}
+	for _, u := range idx.unindexed {
+		if u.pos >= end {
+			break
+		}
+		if u.value.Equal(want) {
+			return u.def, true
+		}
+	}
+	if found {
+		return e.def, true
+	}
+	return nil, false
+}
+
+// find returns the first definition whose field value satisfies eq.
+func (idx *fieldIndex) find(eq func(v errdef.FieldValue) bool) (errdef.Definition, bool) {
+	for _, e := range idx.entries {
+		if eq(e.value) {
+			return e.def, true // First definition wins
+		}
+	}
+	return nil, false
+}
+
+// isIndexable reports whether v can be a key of fieldIndex.byValue, which
+// requires that == on v agrees with FieldValue.Equal.
+func isIndexable(v any) bool {
+	switch v.(type) {
+	case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, bool:
+		return true
+	case time.Time, *url.URL: // Compared with Equal and String, respectively
+		return false
+	}
+	return reflect.ValueOf(v).Comparable()
+}
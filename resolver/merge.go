@@ -0,0 +1,206 @@
+package resolver
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/shiwano/errdef"
+)
+
+// Conflict describes a kind or a field value claimed by definitions from
+// different resolvers while merging them. The first definition wins.
+type Conflict struct {
+	// Kind is the conflicting kind, or empty for a field conflict.
+	Kind errdef.Kind
+	// Key is the key of the conflicting field, or nil for a kind conflict.
+	Key errdef.FieldKey
+	// Value is the conflicting field value, or nil for a kind conflict.
+	Value any
+	// Definitions are the conflicting definitions in resolution order.
+	Definitions []errdef.Definition
+}
+
+// String implements fmt.Stringer.
+func (c Conflict) String() string {
+	if c.Key == nil {
+		return fmt.Sprintf("kind %q is claimed by %d definitions", c.Kind, len(c.Definitions))
+	}
+	kinds := make([]string, len(c.Definitions))
+	for i, def := range c.Definitions {
+		kinds[i] = string(def.Kind())
+	}
+	return fmt.Sprintf("field %s=%v is claimed by %s", c.Key, c.Value, strings.Join(kinds, ", "))
+}
+
+// MergeOption is a function type for customizing Merge.
+type MergeOption func(*mergeConfig)
+
+type mergeConfig struct {
+	fieldKeys map[errdef.FieldKey]struct{}
+}
+
+// flagFieldKeys are the keys of the built-in boolean fields, which are shared
+// by many definitions and do not identify one.
+var flagFieldKeys = []errdef.FieldKey{
+	errdef.Public.Key(),
+	errdef.Retryable.Key(),
+	errdef.Unreportable.Key(),
+	errdef.Bug.Key(),
+}
+
+// WithConflictFields sets the keys of the fields whose values are checked for
+// conflicts by Merge. By default, all fields except the built-in boolean
+// fields (Public, Retryable, Unreportable, and Bug) are checked.
+func WithConflictFields(keys ...errdef.FieldKey) MergeOption {
+	return func(c *mergeConfig) {
+		c.fieldKeys = make(map[errdef.FieldKey]struct{}, len(keys))
+		for _, key := range keys {
+			c.fieldKeys[key] = struct{}{}
+		}
+	}
+}
+
+// Merge creates a new StrictResolver with the definitions and the aliases
+// of the given resolvers, so that they are looked up in a single index.
+// The earlier resolvers take precedence, and the kinds of the definitions
+// take precedence over aliases. Hooks set by OnAlias are not merged.
+//
+// It also returns the conflicts: the kinds, including aliases, and the field
+// values that are claimed by definitions from different resolvers, in order
+// of appearance. Claims within a single resolver are not conflicts.
+func Merge(resolvers []*StrictResolver, opts ...MergeOption) (*StrictResolver, []Conflict) {
+	cfg := &mergeConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var (
+		defs    []errdef.Definition
+		sources []int
+	)
+	seen := make(map[errdef.Definition]struct{})
+	for i, r := range resolvers {
+		for _, def := range r.defs {
+			if _, ok := seen[def]; !ok {
+				seen[def] = struct{}{}
+				defs = append(defs, def)
+				sources = append(sources, i)
+			}
+		}
+	}
+
+	kinds := &conflictSet{}
+	for i, def := range defs {
+		kinds.addKind(def.Kind(), def, sources[i])
+	}
+	aliases := make(map[errdef.Kind]errdef.Definition)
+	for i, r := range resolvers {
+		for _, alias := range slices.Sorted(maps.Keys(r.aliases)) {
+			def := r.aliases[alias]
+			kinds.addKind(alias, def, i)
+			if _, ok := aliases[alias]; !ok {
+				aliases[alias] = def
+			}
+		}
+	}
+
+	fields := &conflictSet{}
+	for i, def := range defs {
+		for key, v := range def.Fields().All() {
+			if cfg.checksField(key) {
+				fields.addField(key, v, def, sources[i])
+			}
+		}
+	}
+
+	merged := New(defs...)
+	if len(aliases) > 0 {
+		merged.aliases = aliases
+	}
+	return merged, append(kinds.conflicts(), fields.conflicts()...)
+}
+
+func (c *mergeConfig) checksField(key errdef.FieldKey) bool {
+	if c.fieldKeys != nil {
+		_, ok := c.fieldKeys[key]
+		return ok
+	}
+	return !slices.Contains(flagFieldKeys, key)
+}
+
+// conflictSet groups the definitions by the kinds or the field values they
+// claim, in order of appearance.
+type conflictSet struct {
+	groups    []*claim
+	index     map[any]*claim
+	unindexed []*claim
+}
+
+// claim is a kind or a field value with the definitions that claim it and
+// the indexes of the resolvers they come from.
+type claim struct {
+	Conflict
+	sources []int
+}
+
+type fieldClaim struct {
+	key   errdef.FieldKey
+	value any
+}
+
+func (s *conflictSet) addKind(kind errdef.Kind, def errdef.Definition, source int) {
+	s.add(kind, Conflict{Kind: kind}, def, source)
+}
+
+func (s *conflictSet) addField(key errdef.FieldKey, v errdef.FieldValue, def errdef.Definition, source int) {
+	value := v.Value()
+	if isIndexable(value) {
+		s.add(fieldClaim{key, value}, Conflict{Key: key, Value: value}, def, source)
+		return
+	}
+
+	for _, c := range s.unindexed {
+		if c.Key == key && v.Equal(c.Value) {
+			c.append(def, source)
+			return
+		}
+	}
+	c := &claim{Conflict: Conflict{Key: key, Value: value}}
+	c.append(def, source)
+	s.groups = append(s.groups, c)
+	s.unindexed = append(s.unindexed, c)
+}
+
+func (s *conflictSet) add(id any, group Conflict, def errdef.Definition, source int) {
+	if s.index == nil {
+		s.index = make(map[any]*claim)
+	}
+	c, ok := s.index[id]
+	if !ok {
+		c = &claim{Conflict: group}
+		s.index[id] = c
+		s.groups = append(s.groups, c)
+	}
+	c.append(def, source)
+}
+
+func (s *conflictSet) conflicts() []Conflict {
+	var conflicts []Conflict
+	for _, c := range s.groups {
+		if len(c.Definitions) > 1 && len(c.sources) > 1 {
+			conflicts = append(conflicts, c.Conflict)
+		}
+	}
+	return conflicts
+}
+
+func (c *claim) append(def errdef.Definition, source int) {
+	if !slices.Contains(c.Definitions, def) {
+		c.Definitions = append(c.Definitions, def)
+	}
+	if !slices.Contains(c.sources, source) {
+		c.sources = append(c.sources, source)
+	}
+}
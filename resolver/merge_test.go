@@ -0,0 +1,162 @@
+package resolver_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/resolver"
+)
+
+func TestMerge(t *testing.T) {
+	svcNotFound := errdef.Define("not_found", errdef.HTTPStatus(404))
+	svcQuota := errdef.Define("quota_exceeded", errdef.HTTPStatus(429))
+	libNotFound := errdef.Define("not_found", errdef.HTTPStatus(404))
+	libRateLimit := errdef.Define("rate_limited", errdef.HTTPStatus(429))
+	shared := errdef.Define("internal", errdef.HTTPStatus(500))
+
+	svc := resolver.New(svcNotFound, svcQuota, shared)
+	lib := resolver.New(libNotFound, libRateLimit, shared).
+		WithAliases(map[errdef.Kind]errdef.Definition{"too_many_requests": libRateLimit, "quota_exceeded": libRateLimit})
+
+	r, conflicts := resolver.Merge([]*resolver.StrictResolver{svc, lib})
+
+	t.Run("resolves in order of resolvers", func(t *testing.T) {
+		tests := []struct {
+			kind errdef.Kind
+			want errdef.Definition
+		}{
+			{"not_found", svcNotFound},
+			{"quota_exceeded", svcQuota},
+			{"rate_limited", libRateLimit},
+			{"too_many_requests", libRateLimit},
+			{"internal", shared},
+		}
+		for _, tt := range tests {
+			if result, ok := r.ResolveKind(tt.kind); !ok || result != tt.want {
+				t.Errorf("%s: want %v, got %v", tt.kind, tt.want, result)
+			}
+		}
+		if result, ok := r.ResolveField(errdef.HTTPStatus.Key(), 429); !ok || result != svcQuota {
+			t.Errorf("want svcQuota, got %v", result)
+		}
+		if want := []errdef.Definition{svcNotFound, svcQuota, shared, libNotFound, libRateLimit}; !reflect.DeepEqual(r.Definitions(), want) {
+			t.Errorf("want definitions %v, got %v", want, r.Definitions())
+		}
+	})
+
+	t.Run("reports conflicts", func(t *testing.T) {
+		want := []resolver.Conflict{
+			{Kind: "not_found", Definitions: []errdef.Definition{svcNotFound, libNotFound}},
+			{Kind: "quota_exceeded", Definitions: []errdef.Definition{svcQuota, libRateLimit}},
+			{Key: errdef.HTTPStatus.Key(), Value: 404, Definitions: []errdef.Definition{svcNotFound, libNotFound}},
+			{Key: errdef.HTTPStatus.Key(), Value: 429, Definitions: []errdef.Definition{svcQuota, libRateLimit}},
+		}
+		if !reflect.DeepEqual(conflicts, want) {
+			t.Errorf("want %v, got %v", want, conflicts)
+		}
+	})
+
+	t.Run("groups non-comparable field values", func(t *testing.T) {
+		tags, _ := errdef.DefineField[[]string]("tags")
+		def1 := errdef.Define("error1", tags([]string{"a"}))
+		def2 := errdef.Define("error2", tags([]string{"a"}))
+		def3 := errdef.Define("error3", tags([]string{"b"}))
+
+		_, conflicts := resolver.Merge([]*resolver.StrictResolver{resolver.New(def1, def3), resolver.New(def2)})
+
+		if len(conflicts) != 1 || !reflect.DeepEqual(conflicts[0].Definitions, []errdef.Definition{def1, def2}) {
+			t.Errorf("want one conflict of def1 and def2, got %v", conflicts)
+		}
+	})
+
+	t.Run("ignores claims within a single resolver", func(t *testing.T) {
+		a := errdef.Define("a", errdef.HTTPStatus(400))
+		b := errdef.Define("b", errdef.HTTPStatus(400))
+		c := errdef.Define("c", errdef.HTTPStatus(500))
+
+		_, conflicts := resolver.Merge([]*resolver.StrictResolver{resolver.New(a, b), resolver.New(c)})
+		if len(conflicts) != 0 {
+			t.Errorf("want no conflicts, got %v", conflicts)
+		}
+	})
+
+	t.Run("reports all definitions of a value claimed across resolvers", func(t *testing.T) {
+		a := errdef.Define("a", errdef.HTTPStatus(400))
+		b := errdef.Define("b", errdef.HTTPStatus(400))
+		c := errdef.Define("c", errdef.HTTPStatus(400))
+
+		_, conflicts := resolver.Merge([]*resolver.StrictResolver{resolver.New(a, b), resolver.New(c)})
+		want := []resolver.Conflict{
+			{Key: errdef.HTTPStatus.Key(), Value: 400, Definitions: []errdef.Definition{a, b, c}},
+		}
+		if !reflect.DeepEqual(conflicts, want) {
+			t.Errorf("want %v, got %v", want, conflicts)
+		}
+	})
+
+	t.Run("ignores built-in boolean fields", func(t *testing.T) {
+		a := errdef.Define("a", errdef.Public(), errdef.Retryable())
+		b := errdef.Define("b", errdef.Public(), errdef.Unreportable(), errdef.Bug())
+		c := errdef.Define("c", errdef.Public(), errdef.Retryable(), errdef.Unreportable(), errdef.Bug())
+
+		_, conflicts := resolver.Merge([]*resolver.StrictResolver{resolver.New(a, b), resolver.New(c)})
+		if len(conflicts) != 0 {
+			t.Errorf("want no conflicts, got %v", conflicts)
+		}
+	})
+
+	t.Run("checks the given fields", func(t *testing.T) {
+		code, _ := errdef.DefineField[string]("code")
+		a := errdef.Define("a", errdef.HTTPStatus(400), code("E1"), errdef.Retryable())
+		b := errdef.Define("b", errdef.HTTPStatus(400), code("E1"), errdef.Retryable())
+
+		_, conflicts := resolver.Merge(
+			[]*resolver.StrictResolver{resolver.New(a), resolver.New(b)},
+			resolver.WithConflictFields(code.Key(), errdef.Retryable.Key()),
+		)
+		want := []resolver.Conflict{
+			{Key: code.Key(), Value: "E1", Definitions: []errdef.Definition{a, b}},
+			{Key: errdef.Retryable.Key(), Value: true, Definitions: []errdef.Definition{a, b}},
+		}
+		if !reflect.DeepEqual(conflicts, want) {
+			t.Errorf("want %v, got %v", want, conflicts)
+		}
+	})
+
+	t.Run("has no conflicts for distinct resolvers", func(t *testing.T) {
+		_, conflicts := resolver.Merge([]*resolver.StrictResolver{resolver.New(svcNotFound), resolver.New(libRateLimit)})
+		if len(conflicts) != 0 {
+			t.Errorf("want no conflicts, got %v", conflicts)
+		}
+	})
+}
+
+func TestConflict_String(t *testing.T) {
+	def1 := errdef.Define("error1", errdef.HTTPStatus(404))
+	def2 := errdef.Define("error2", errdef.HTTPStatus(404))
+
+	tests := []struct {
+		name     string
+		conflict resolver.Conflict
+		want     string
+	}{
+		{
+			name:     "kind",
+			conflict: resolver.Conflict{Kind: "error1", Definitions: []errdef.Definition{def1, def1}},
+			want:     `kind "error1" is claimed by 2 definitions`,
+		},
+		{
+			name:     "field",
+			conflict: resolver.Conflict{Key: errdef.HTTPStatus.Key(), Value: 404, Definitions: []errdef.Definition{def1, def2}},
+			want:     "field http_status=404 is claimed by error1, error2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.conflict.String(); got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -37,5 +37,12 @@ func New(defs ...errdef.Definition) *StrictResolver {
 	return &StrictResolver{
 		defs:   defs,
 		byKind: byKind,
+		fields: newFieldIndexes(defs),
 	}
 }
+
+// FromCatalog creates a new Resolver with the definitions of the catalog
+// loaded by errdef.LoadCatalog.
+func FromCatalog(c *errdef.Catalog) *StrictResolver {
+	return New(c.Definitions()...)
+}
@@ -1,6 +1,7 @@
 package resolver_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/shiwano/errdef"
@@ -34,3 +35,20 @@ func TestNew(t *testing.T) {
 		}
 	})
 }
+
+func TestFromCatalog(t *testing.T) {
+	c, err := errdef.LoadCatalog(strings.NewReader(`{"kinds": [{"kind": "error1"}, {"kind": "error2"}]}`))
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	r := resolver.FromCatalog(c)
+
+	def, ok := r.ResolveKind("error2")
+	if !ok {
+		t.Fatal("want to resolve existing kind")
+	}
+	if want, _ := c.Definition("error2"); def != want {
+		t.Error("want the catalog definition")
+	}
+}
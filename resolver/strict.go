@@ -1,14 +1,22 @@
 package resolver
 
 import (
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
 	"github.com/shiwano/errdef"
 )
 
 // StrictResolver manages multiple error definitions and provides resolution
 // functionality based on Kind or Field criteria.
 type StrictResolver struct {
-	defs   []errdef.Definition
-	byKind map[errdef.Kind]errdef.Definition
+	defs    []errdef.Definition
+	byKind  map[errdef.Kind]errdef.Definition
+	aliases map[errdef.Kind]errdef.Definition
+	onAlias func(alias errdef.Kind, def errdef.Definition)
+	fields  *fieldIndexes
 }
 
 var _ Resolver = (*StrictResolver)(nil)
@@ -16,37 +24,134 @@ var _ Resolver = (*StrictResolver)(nil)
 // WithDefault creates a new DefaultResolver that uses the given definition
 // as a default when resolution fails.
 func (r *StrictResolver) WithDefault(defaultDef errdef.Definition) *DefaultResolver {
-	return &DefaultResolver{
-		resolver:   r,
-		defaultDef: defaultDef,
+	return WithDefault(r, defaultDef)
+}
+
+// WithAliases creates a new StrictResolver that also resolves the given
+// legacy kinds to their definitions, so that a kind can be renamed while
+// consumers still send the old one. The kinds of the definitions take
+// precedence over aliases, and later aliases override earlier ones.
+func (r *StrictResolver) WithAliases(aliases map[errdef.Kind]errdef.Definition) *StrictResolver {
+	merged := maps.Clone(r.aliases)
+	if merged == nil {
+		merged = make(map[errdef.Kind]errdef.Definition, len(aliases))
 	}
+	maps.Copy(merged, aliases)
+
+	clone := *r
+	clone.aliases = merged
+	return &clone
+}
+
+// OnAlias creates a new StrictResolver that calls fn whenever ResolveKind
+// resolves a kind through an alias, e.g., to record a metric that shows
+// which producers still send legacy kinds.
+func (r *StrictResolver) OnAlias(fn func(alias errdef.Kind, def errdef.Definition)) *StrictResolver {
+	clone := *r
+	clone.onAlias = fn
+	return &clone
+}
+
+// Definitions returns the definitions of the resolver in resolution order.
+func (r *StrictResolver) Definitions() []errdef.Definition {
+	return slices.Clone(r.defs)
 }
 
 // ResolveKind implements Resolver.
 func (r *StrictResolver) ResolveKind(kind errdef.Kind) (errdef.Definition, bool) {
-	def, ok := r.byKind[kind]
-	return def, ok
+	if def, ok := r.byKind[kind]; ok {
+		return def, true
+	}
+	if def, ok := r.aliases[kind]; ok {
+		if r.onAlias != nil {
+			r.onAlias(kind, def)
+		}
+		return def, true
+	}
+	return nil, false
+}
+
+// ResolvePrefix returns the definitions whose kinds start with prefix
+// (e.g., "payment."), in resolution order.
+func (r *StrictResolver) ResolvePrefix(prefix string) []errdef.Definition {
+	var defs []errdef.Definition
+	for _, def := range r.defs {
+		if strings.HasPrefix(string(def.Kind()), prefix) && r.byKind[def.Kind()] == def {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
+
+// ResolveMatch returns the definitions whose kinds match pattern, in
+// resolution order. Kinds are namespaced by dots, and each dot-separated
+// segment of pattern is matched against the segment of a kind with the
+// syntax of path.Match, so "payment.*.declined" matches
+// "payment.card.declined" but not "payment.card.visa.declined".
+// The only possible error is path.ErrBadPattern, when pattern is malformed.
+func (r *StrictResolver) ResolveMatch(pattern string) ([]errdef.Definition, error) {
+	patterns := strings.Split(pattern, ".")
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	var defs []errdef.Definition
+	for _, def := range r.defs {
+		if r.byKind[def.Kind()] == def && matchKind(patterns, string(def.Kind())) {
+			defs = append(defs, def)
+		}
+	}
+	return defs, nil
+}
+
+// ResolveNamespace resolves the definition of the longest namespace of kind
+// for which a definition exists, e.g., "payment.card" or "payment" for
+// "payment.card.declined". The kind itself is not resolved.
+func (r *StrictResolver) ResolveNamespace(kind errdef.Kind) (errdef.Definition, bool) {
+	for ns, ok := parentNamespace(kind); ok; ns, ok = parentNamespace(ns) {
+		if def, ok := r.ResolveKind(ns); ok {
+			return def, true
+		}
+	}
+	return nil, false
 }
 
 // ResolveField implements Resolver.
+// Comparable values are looked up in an index per field key, which is built
+// on the first resolution by the key.
 func (r *StrictResolver) ResolveField(key errdef.FieldKey, want any) (errdef.Definition, bool) {
-	return r.ResolveFieldFunc(key, func(v errdef.FieldValue) bool {
-		if fv, ok := want.(errdef.FieldValue); ok {
-			return v.Equal(fv.Value())
-		}
-		return v.Equal(want)
-	})
+	if fv, ok := want.(errdef.FieldValue); ok {
+		want = fv.Value()
+	}
+	return r.fields.get(key).lookup(want)
 }
 
 // ResolveFieldFunc implements Resolver.
 func (r *StrictResolver) ResolveFieldFunc(key errdef.FieldKey, eq func(v errdef.FieldValue) bool) (errdef.Definition, bool) {
-	for _, def := range r.defs {
-		v, ok := def.Fields().Get(key)
-		if !ok || !eq(v) {
-			continue
+	return r.fields.get(key).find(eq)
+}
+
+func matchKind(patterns []string, kind string) bool {
+	segments := strings.Split(kind, ".")
+	if len(segments) != len(patterns) {
+		return false
+	}
+	for i, p := range patterns {
+		if ok, _ := path.Match(p, segments[i]); !ok {
+			return false
 		}
+	}
+	return true
+}
 
-		return def, true // First definition wins
+// parentNamespace returns the namespace of kind, which is the part before
+// its last dot.
+func parentNamespace(kind errdef.Kind) (errdef.Kind, bool) {
+	i := strings.LastIndexByte(string(kind), '.')
+	if i < 0 {
+		return "", false
 	}
-	return nil, false
+	return kind[:i], true
 }
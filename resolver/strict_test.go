@@ -1,7 +1,12 @@
 package resolver_test
 
 import (
+	"errors"
+	"path"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/shiwano/errdef"
 	"github.com/shiwano/errdef/resolver"
@@ -52,6 +57,166 @@ func TestStrictResolver_ResolveKind(t *testing.T) {
 	})
 }
 
+func TestStrictResolver_WithAliases(t *testing.T) {
+	def1 := errdef.Define("error1")
+	def2 := errdef.Define("error2")
+	base := resolver.New(def1, def2)
+	r := base.WithAliases(map[errdef.Kind]errdef.Definition{
+		"legacy1": def1,
+		"error2":  def1, // shadowed by the kind of def2
+	})
+
+	t.Run("resolves alias", func(t *testing.T) {
+		result, ok := r.ResolveKind("legacy1")
+		if !ok || result != def1 {
+			t.Errorf("want def1, got %v (ok=%v)", result, ok)
+		}
+	})
+
+	t.Run("kind takes precedence over alias", func(t *testing.T) {
+		result, ok := r.ResolveKind("error2")
+		if !ok || result != def2 {
+			t.Errorf("want def2, got %v (ok=%v)", result, ok)
+		}
+	})
+
+	t.Run("does not modify the original resolver", func(t *testing.T) {
+		if _, ok := base.ResolveKind("legacy1"); ok {
+			t.Error("want original resolver not to resolve alias")
+		}
+	})
+
+	t.Run("later aliases override earlier ones", func(t *testing.T) {
+		r := r.WithAliases(map[errdef.Kind]errdef.Definition{"legacy1": def2})
+		result, ok := r.ResolveKind("legacy1")
+		if !ok || result != def2 {
+			t.Errorf("want def2, got %v (ok=%v)", result, ok)
+		}
+	})
+
+	t.Run("works with default resolver", func(t *testing.T) {
+		defaultDef := errdef.Define("default")
+		dr := r.WithDefault(defaultDef)
+		if result := dr.ResolveKindOrDefault("legacy1"); result != def1 {
+			t.Errorf("want def1, got %v", result)
+		}
+		if result := dr.ResolveKindOrDefault("unknown"); result != defaultDef {
+			t.Errorf("want default, got %v", result)
+		}
+	})
+}
+
+func TestStrictResolver_OnAlias(t *testing.T) {
+	def1 := errdef.Define("error1")
+
+	var calls []errdef.Kind
+	r := resolver.New(def1).
+		WithAliases(map[errdef.Kind]errdef.Definition{"legacy1": def1}).
+		OnAlias(func(alias errdef.Kind, def errdef.Definition) {
+			if def != def1 {
+				t.Errorf("want def1, got %v", def)
+			}
+			calls = append(calls, alias)
+		})
+
+	r.ResolveKind("error1")
+	r.ResolveKind("legacy1")
+	r.ResolveKind("unknown")
+
+	if len(calls) != 1 || calls[0] != "legacy1" {
+		t.Errorf("want one call for legacy1, got %v", calls)
+	}
+}
+
+func TestStrictResolver_ResolvePrefix(t *testing.T) {
+	declined := errdef.Define("payment.card.declined")
+	expired := errdef.Define("payment.card.expired")
+	refund := errdef.Define("payment.refund")
+	shipping := errdef.Define("shipping.lost")
+	duplicate := errdef.Define("payment.refund")
+	r := resolver.New(declined, shipping, expired, refund, duplicate)
+
+	tests := []struct {
+		prefix string
+		want   []errdef.Definition
+	}{
+		{"payment.", []errdef.Definition{declined, expired, refund}},
+		{"payment.card.", []errdef.Definition{declined, expired}},
+		{"", []errdef.Definition{declined, shipping, expired, refund}},
+		{"billing.", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			if got := r.ResolvePrefix(tt.prefix); !slices.Equal(got, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStrictResolver_ResolveMatch(t *testing.T) {
+	cardDeclined := errdef.Define("payment.card.declined")
+	bankDeclined := errdef.Define("payment.bank.declined")
+	visaDeclined := errdef.Define("payment.card.visa.declined")
+	expired := errdef.Define("payment.card.expired")
+	r := resolver.New(cardDeclined, bankDeclined, visaDeclined, expired)
+
+	tests := []struct {
+		pattern string
+		want    []errdef.Definition
+	}{
+		{"payment.*.declined", []errdef.Definition{cardDeclined, bankDeclined}},
+		{"payment.card.*", []errdef.Definition{cardDeclined, expired}},
+		{"*.*.*.declined", []errdef.Definition{visaDeclined}},
+		{"payment.card.e?pired", []errdef.Definition{expired}},
+		{"payment.*", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got, err := r.ResolveMatch(tt.pattern)
+			if err != nil {
+				t.Fatalf("want no error, got %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("returns error for malformed pattern", func(t *testing.T) {
+		if _, err := r.ResolveMatch("payment.[card"); !errors.Is(err, path.ErrBadPattern) {
+			t.Errorf("want ErrBadPattern, got %v", err)
+		}
+	})
+}
+
+func TestStrictResolver_ResolveNamespace(t *testing.T) {
+	payment := errdef.Define("payment")
+	card := errdef.Define("payment.card")
+	r := resolver.New(payment, card)
+
+	tests := []struct {
+		kind   errdef.Kind
+		want   errdef.Definition
+		wantOk bool
+	}{
+		{"payment.card.declined", card, true},
+		{"payment.card.visa.declined", card, true},
+		{"payment.refund", payment, true},
+		{"payment.card", payment, true},
+		{"payment", nil, false},
+		{"shipping.lost", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			got, ok := r.ResolveNamespace(tt.kind)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("want %v (ok=%v), got %v (ok=%v)", tt.want, tt.wantOk, got, ok)
+			}
+		})
+	}
+}
+
 func TestStrictResolver_ResolveField(t *testing.T) {
 	ctor1, _ := errdef.DefineField[string]("test_field")
 	ctor2, _ := errdef.DefineField[int]("number_field")
@@ -145,6 +310,81 @@ func TestStrictResolver_ResolveField(t *testing.T) {
 	})
 }
 
+func TestStrictResolver_ResolveField_Index(t *testing.T) {
+	t.Run("first definition wins for duplicate values", func(t *testing.T) {
+		ctor, _ := errdef.DefineField[int]("code")
+		def1 := errdef.Define("error1", ctor(1))
+		def2 := errdef.Define("error2", ctor(2))
+		def3 := errdef.Define("error3", ctor(1))
+		r := resolver.New(def1, def2, def3)
+
+		if result, ok := r.ResolveField(ctor.Key(), 1); !ok || result != def1 {
+			t.Errorf("want def1, got %v", result)
+		}
+	})
+
+	t.Run("keeps order of non-comparable values", func(t *testing.T) {
+		ctor, _ := errdef.DefineField[any]("value")
+		defSlice := errdef.Define("slice", ctor([]int{1}))
+		defInt := errdef.Define("int", ctor(1))
+		r := resolver.New(defSlice, defInt)
+
+		tests := []struct {
+			name string
+			want any
+			def  errdef.Definition
+		}{
+			{"comparable", 1, defInt},
+			{"slice", []int{1}, defSlice},
+			{"different type", int64(1), nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				result, ok := r.ResolveField(ctor.Key(), tt.want)
+				if ok != (tt.def != nil) || result != tt.def {
+					t.Errorf("want %v, got %v", tt.def, result)
+				}
+			})
+		}
+	})
+
+	t.Run("compares time with Equal", func(t *testing.T) {
+		ctor, _ := errdef.DefineField[time.Time]("at")
+		at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		def := errdef.Define("error", ctor(at))
+		r := resolver.New(def)
+
+		if result, ok := r.ResolveField(ctor.Key(), at.In(time.FixedZone("JST", 9*60*60))); !ok || result != def {
+			t.Errorf("want def, got %v", result)
+		}
+	})
+
+	t.Run("resolves by field value", func(t *testing.T) {
+		def := errdef.Define("error", errdef.HTTPStatus(404))
+		r := resolver.New(def)
+
+		want, _ := errdef.HTTPStatus.Key().NewValue(404)
+		if result, ok := r.ResolveField(errdef.HTTPStatus.Key(), want); !ok || result != def {
+			t.Errorf("want def, got %v", result)
+		}
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		def := errdef.Define("error", errdef.HTTPStatus(404))
+		r := resolver.New(def)
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Go(func() {
+				if result, ok := r.ResolveField(errdef.HTTPStatus.Key(), 404); !ok || result != def {
+					t.Errorf("want def, got %v", result)
+				}
+			})
+		}
+		wg.Wait()
+	})
+}
+
 func TestStrictResolver_ResolveFieldFunc(t *testing.T) {
 	ctor, _ := errdef.DefineField[string]("test_field")
 
@@ -197,3 +437,34 @@ func TestStrictResolver_ResolveFieldFunc(t *testing.T) {
 		}
 	})
 }
+
+func TestStrictResolver_Definitions(t *testing.T) {
+	def1 := errdef.Define("error1")
+	def2 := errdef.Define("error2")
+	r := resolver.New(def1, def2)
+
+	defs := r.Definitions()
+	if len(defs) != 2 || defs[0] != def1 || defs[1] != def2 {
+		t.Errorf("want definitions in order, got %v", defs)
+	}
+
+	defs[0] = def2
+	if r.Definitions()[0] != def1 {
+		t.Error("want a copy of the definitions")
+	}
+}
+
+func TestStrictResolver_ZeroValue(t *testing.T) {
+	var r resolver.StrictResolver
+	ctor, _ := errdef.DefineField[string]("test_field")
+
+	if def, ok := r.ResolveKind("error1"); ok || def != nil {
+		t.Errorf("want no kind resolution, got %v", def)
+	}
+	if def, ok := r.ResolveField(ctor.Key(), "hello"); ok || def != nil {
+		t.Errorf("want no field resolution, got %v", def)
+	}
+	if def, ok := r.ResolveFieldFunc(ctor.Key(), func(errdef.FieldValue) bool { return true }); ok || def != nil {
+		t.Errorf("want no field func resolution, got %v", def)
+	}
+}
@@ -0,0 +1,186 @@
+package errdef
+
+import (
+	"regexp"
+	"sync/atomic"
+)
+
+// Scrubber rewrites an error message to remove sensitive data such as
+// emails, tokens, or SQL literals before it is presented.
+//
+// Scrubbers are applied at presentation time (fmt, json, slog) to the messages
+// of the whole cause tree, while Error() keeps returning the raw message.
+type Scrubber func(msg string) string
+
+var (
+	globalScrubber atomic.Pointer[Scrubber]
+	// definitionScrubberUsed lets presenters skip walking the cause tree
+	// for scrubbers when no definition has one.
+	definitionScrubberUsed atomic.Bool
+
+	emailPattern       = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	bearerTokenPattern = regexp.MustCompile(`(?i)\b(bearer)\s+[A-Za-z0-9\-._~+/]+=*`)
+	creditCardPattern  = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+)
+
+// SetMessageScrubber sets the scrubber that is applied globally to the messages
+// of all errors at presentation time, before any per-definition scrubber
+// set by the MessageScrubber option. Pass nil to remove it.
+func SetMessageScrubber(s Scrubber) {
+	if s == nil {
+		globalScrubber.Store(nil)
+		return
+	}
+	globalScrubber.Store(&s)
+}
+
+// GlobalMessageScrubber returns the scrubber set by SetMessageScrubber, or nil if there is none.
+func GlobalMessageScrubber() Scrubber {
+	return loadGlobalScrubber()
+}
+
+// MessageScrubberOf returns the scrubber set by the MessageScrubber option of
+// the definition of err, or nil if there is none. The global scrubber is not included.
+//
+// Presenters apply the global scrubber, the scrubbers of the error's ancestors
+// and CauseMessageScrubberOf of the error to the message of each error in the
+// cause tree. Use these in custom marshalers to scrub messages in the same way.
+func MessageScrubberOf(err error) Scrubber {
+	return ownScrubber(err)
+}
+
+// CauseMessageScrubberOf returns the scrubbers set by the MessageScrubber option
+// of the definitions of err and of every error in its cause tree, or nil if there
+// are none. The global scrubber is not included.
+//
+// Messages created by Wrap and Wrapf embed the messages of their causes,
+// so they must be scrubbed with the scrubbers of the causes as well.
+func CauseMessageScrubberOf(err error) Scrubber {
+	return causeScrubber(err)
+}
+
+// ChainScrubbers returns a Scrubber that applies the given scrubbers in order.
+// Nil scrubbers are ignored.
+func ChainScrubbers(scrubbers ...Scrubber) Scrubber {
+	var chain []Scrubber
+	for _, s := range scrubbers {
+		if s != nil {
+			chain = append(chain, s)
+		}
+	}
+	switch len(chain) {
+	case 0:
+		return nil
+	case 1:
+		return chain[0]
+	}
+	return func(msg string) string {
+		for _, s := range chain {
+			msg = s(msg)
+		}
+		return msg
+	}
+}
+
+// ScrubRegexp returns a Scrubber that replaces all matches of re with replacement.
+// The replacement may contain $1-style references as in regexp.Regexp.ReplaceAllString.
+func ScrubRegexp(re *regexp.Regexp, replacement string) Scrubber {
+	return func(msg string) string {
+		return re.ReplaceAllString(msg, replacement)
+	}
+}
+
+// ScrubEmails returns a Scrubber that replaces email addresses with "[REDACTED]".
+func ScrubEmails() Scrubber {
+	return ScrubRegexp(emailPattern, redactedStr)
+}
+
+// ScrubBearerTokens returns a Scrubber that replaces bearer tokens
+// (e.g., "Bearer eyJhbGci...") with "Bearer [REDACTED]".
+func ScrubBearerTokens() Scrubber {
+	return ScrubRegexp(bearerTokenPattern, "${1} "+redactedStr)
+}
+
+// ScrubCreditCards returns a Scrubber that replaces credit card numbers with "[REDACTED]".
+// Only digit sequences (optionally separated by spaces or hyphens) that pass
+// the Luhn checksum are replaced.
+func ScrubCreditCards() Scrubber {
+	return func(msg string) string {
+		return creditCardPattern.ReplaceAllStringFunc(msg, func(match string) string {
+			if !isLuhnValid(match) {
+				return match
+			}
+			return redactedStr
+		})
+	}
+}
+
+func (s Scrubber) apply(msg string) string {
+	if s == nil {
+		return msg
+	}
+	return s(msg)
+}
+
+func loadGlobalScrubber() Scrubber {
+	if s := globalScrubber.Load(); s != nil {
+		return *s
+	}
+	return nil
+}
+
+// ownScrubber returns the per-definition scrubber of err, if any.
+func ownScrubber(err error) Scrubber {
+	if e, ok := err.(*definedError); ok {
+		return e.def.messageScrubber
+	}
+	return nil
+}
+
+// causeScrubber returns the per-definition scrubbers of err and its cause tree,
+// applying each definition's scrubber only once.
+func causeScrubber(err error) Scrubber {
+	if !definitionScrubberUsed.Load() {
+		return nil
+	}
+
+	var (
+		scrubbers []Scrubber
+		seen      map[*definition]struct{}
+	)
+	for _, node := range buildNodes([]error{err}, make(map[uintptr]uintptr)).Walk() {
+		e, ok := node.Error.(*definedError)
+		if !ok || e.def.messageScrubber == nil {
+			continue
+		}
+		if _, ok := seen[e.def]; ok {
+			continue
+		}
+		if seen == nil {
+			seen = make(map[*definition]struct{})
+		}
+		seen[e.def] = struct{}{}
+		scrubbers = append(scrubbers, e.def.messageScrubber)
+	}
+	return ChainScrubbers(scrubbers...)
+}
+
+func isLuhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
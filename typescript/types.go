@@ -0,0 +1,127 @@
+package typescript
+
+import (
+	"encoding"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	durationType      = reflect.TypeFor[time.Duration]()
+	levelType         = reflect.TypeFor[slog.Level]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+)
+
+// typeOf returns the TypeScript type of the JSON encoding of values of type t.
+// Object types are written across lines with the given indent.
+// A nil type (e.g., the type of an interface field) is unknown.
+func typeOf(t reflect.Type, indent string) string {
+	return (&typeVisitor{visiting: make(map[reflect.Type]bool)}).typeOf(t, indent)
+}
+
+type typeVisitor struct {
+	visiting map[reflect.Type]bool
+}
+
+func (v *typeVisitor) typeOf(t reflect.Type, indent string) string {
+	if t == nil {
+		return "unknown"
+	}
+
+	switch {
+	case t == timeType:
+		return "string" // RFC 3339
+	case t == durationType:
+		return "number" // nanoseconds
+	case t == levelType:
+		return `"DEBUG" | "INFO" | "WARN" | "ERROR" | (string & {})` // e.g., "WARN+2"
+	case implements(t, jsonMarshalerType):
+		if implements(t, textMarshalerType) {
+			return "string" // e.g., Redacted
+		}
+		return "unknown" // the encoding is unknown
+	case implements(t, textMarshalerType):
+		return "string"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Pointer:
+		return v.typeOf(t.Elem(), indent) + " | null"
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 && !implements(t.Elem(), jsonMarshalerType) {
+			return "string" // base64
+		}
+		elem := v.typeOf(t.Elem(), indent)
+		if strings.Contains(elem, " | ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case reflect.Map:
+		switch t.Key().Kind() {
+		case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return "Record<string, " + v.typeOf(t.Elem(), indent) + ">"
+		}
+		return "Record<string, unknown>"
+	case reflect.Struct:
+		return v.structType(t, indent)
+	}
+	return "unknown"
+}
+
+func (v *typeVisitor) structType(t reflect.Type, indent string) string {
+	if v.visiting[t] {
+		return "Record<string, unknown>" // recursive type
+	}
+	v.visiting[t] = true
+	defer delete(v.visiting, t)
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			continue // promoted fields of embedded structs are visited on their own
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		optional := ""
+		if strings.Contains(opts, "omitempty") || strings.Contains(opts, "omitzero") {
+			optional = "?"
+		}
+		b.WriteString(indent + "  " + propertyName(name) + optional + ": " + v.typeOf(f.Type, indent+"  ") + ";\n")
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+func propertyName(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+	return quote(name)
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
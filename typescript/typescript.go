@@ -0,0 +1,217 @@
+// Package typescript generates TypeScript types for errors serialized by errdef.
+//
+// The generated module describes the JSON produced by the default
+// Presenter.MarshalErrorJSON. It declares an interface per Kind with a literal
+// kind and typed fields, a discriminated union of them, the list of kinds,
+// and type guards:
+//
+//	export interface NotFoundError {
+//	  message: string;
+//	  kind: "not_found";
+//	  fields: {
+//	    /** @default 404 */
+//	    http_status: number;
+//	    user_id?: string;
+//	  };
+//	  stack?: ErrdefErrorFrame[];
+//	  causes?: ErrdefErrorCause[];
+//	}
+//
+//	export type ErrdefError = NotFoundError | ConflictError;
+//
+//	export function isNotFoundError(e: unknown): e is NotFoundError
+//
+// Field types are mapped from the Go types of the FieldKeys via reflection.
+// Fields set by the definitions themselves are always known; fields that are
+// attached when errors are created (e.g., with Definition.With) must be given
+// with WithFields or WithKindFields.
+package typescript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/internal/gen"
+)
+
+type (
+	// Generator generates TypeScript types for a set of definitions.
+	Generator struct {
+		defs       []errdef.Definition
+		fields     []errdef.FieldKey
+		kindFields map[errdef.Definition][]errdef.FieldKey
+		name       string
+		names      map[errdef.Definition]string
+	}
+
+	// Option configures a Generator.
+	Option func(*Generator)
+)
+
+var _ io.WriterTo = (*Generator)(nil)
+
+// New creates a new Generator for the given definitions.
+// The order of definitions is kept in the generated union.
+//
+//	g := typescript.New(Resolver.Definitions(), typescript.WithFields(UserID.Key()))
+func New(defs []errdef.Definition, opts ...Option) *Generator {
+	g := &Generator{
+		defs:       defs,
+		kindFields: make(map[errdef.Definition][]errdef.FieldKey),
+		name:       "ErrdefError",
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	reserved := []string{g.name, g.name + "Frame", g.name + "Cause", g.name + "Kind"}
+	g.names = gen.Names(defs, reserved, func(def errdef.Definition) string {
+		name := gen.CamelCase(string(def.Kind())) + "Error"
+		if unicode.IsDigit([]rune(name)[0]) {
+			return "_" + name
+		}
+		return name
+	})
+	return g
+}
+
+// WithFields returns an Option that declares fields that may be attached to
+// errors of any kind.
+func WithFields(keys ...errdef.FieldKey) Option {
+	return func(g *Generator) {
+		g.fields = append(g.fields, keys...)
+	}
+}
+
+// WithKindFields returns an Option that declares fields that may be attached to
+// errors of the given definition.
+func WithKindFields(def errdef.Definition, keys ...errdef.FieldKey) Option {
+	return func(g *Generator) {
+		g.kindFields[def] = append(g.kindFields[def], keys...)
+	}
+}
+
+// WithName returns an Option that sets the name of the union type (default "ErrdefError").
+// The name is also used as the prefix of the supporting types and the kind list.
+func WithName(name string) Option {
+	return func(g *Generator) {
+		g.name = name
+	}
+}
+
+// TypeName returns the name of the interface generated for the definition,
+// which is the kind in CamelCase followed by "Error" (e.g., "NotFoundError").
+func (g *Generator) TypeName(def errdef.Definition) string {
+	return g.names[def]
+}
+
+// WriteTo writes the generated TypeScript module to w.
+// Compile it with tsc --declaration to get a declaration file.
+func (g *Generator) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by errdef/typescript. DO NOT EDIT.\n\n")
+
+	fmt.Fprintf(&b, "/** A frame of the stack trace of an error. */\n")
+	fmt.Fprintf(&b, "export interface %sFrame {\n  func: string;\n  file: string;\n  line: number;\n}\n\n", g.name)
+
+	fmt.Fprintf(&b, "/** A cause of an error: an errdef error, or another error with its Go type. */\n")
+	fmt.Fprintf(&b, "export interface %sCause {\n", g.name)
+	b.WriteString("  message: string;\n  kind?: string;\n  type?: string;\n  fields?: Record<string, unknown>;\n")
+	fmt.Fprintf(&b, "  stack?: %sFrame[];\n  causes?: %sCause[];\n}\n\n", g.name, g.name)
+
+	for _, def := range g.defs {
+		g.writeKind(&b, def)
+	}
+
+	members := make([]string, len(g.defs))
+	kinds := make([]string, len(g.defs))
+	for i, def := range g.defs {
+		members[i] = g.names[def]
+		kinds[i] = quote(string(def.Kind()))
+	}
+	if len(members) == 0 {
+		members = []string{"never"}
+	}
+
+	fmt.Fprintf(&b, "/** An error of any of the kinds. */\n")
+	fmt.Fprintf(&b, "export type %s = %s;\n\n", g.name, strings.Join(members, " | "))
+	fmt.Fprintf(&b, "/** The kinds of %s. */\n", g.name)
+	fmt.Fprintf(&b, "export const %sKinds = [%s] as const;\n\n", lowerFirst(g.name), strings.Join(kinds, ", "))
+	fmt.Fprintf(&b, "export type %sKind = %s[\"kind\"];\n\n", g.name, g.name)
+
+	fmt.Fprintf(&b, "/** Reports whether e is a serialized error of any of the kinds. */\n")
+	fmt.Fprintf(&b, "export function is%s(e: unknown): e is %s {\n", g.name, g.name)
+	b.WriteString("  return (\n    typeof e === \"object\" &&\n    e !== null &&\n")
+	fmt.Fprintf(&b, "    (%sKinds as readonly unknown[]).includes((e as { kind?: unknown }).kind)\n  );\n}\n", lowerFirst(g.name))
+
+	for _, def := range g.defs {
+		name := g.names[def]
+		fmt.Fprintf(&b, "\n/** Reports whether e is a serialized error of kind %s. */\n", quote(string(def.Kind())))
+		fmt.Fprintf(&b, "export function is%s(e: unknown): e is %s {\n", name, name)
+		fmt.Fprintf(&b, "  return is%s(e) && e.kind === %s;\n}\n", g.name, quote(string(def.Kind())))
+	}
+
+	n, err := w.Write(b.Bytes())
+	return int64(n), err
+}
+
+func (g *Generator) writeKind(b *bytes.Buffer, def errdef.Definition) {
+	fields := gen.Fields(def, g.kindFields[def], g.fields)
+
+	fmt.Fprintf(b, "/** %s */\n", gen.KindDescription(def))
+	fmt.Fprintf(b, "export interface %s {\n", g.names[def])
+	b.WriteString("  message: string;\n")
+	fmt.Fprintf(b, "  kind: %s;\n", quote(string(def.Kind())))
+
+	optional := "?"
+	for _, f := range fields {
+		if f.Fixed {
+			optional = ""
+		}
+	}
+	if len(fields) == 0 {
+		b.WriteString("  fields?: Record<string, unknown>;\n")
+	} else {
+		fmt.Fprintf(b, "  fields%s: {\n", optional)
+		for _, f := range fields {
+			typ := typeOf(f.Type, "    ")
+			if f.Sensitive && typ != "string" && typ != "unknown" {
+				typ += " | " + quote(gen.Redacted)
+			}
+			if f.Fixed {
+				data, _ := json.Marshal(f.Value)
+				fmt.Fprintf(b, "    /** @default %s */\n", data)
+				fmt.Fprintf(b, "    %s: %s;\n", propertyName(f.Name), typ)
+			} else {
+				fmt.Fprintf(b, "    %s?: %s;\n", propertyName(f.Name), typ)
+			}
+		}
+		b.WriteString("  };\n")
+	}
+	fmt.Fprintf(b, "  stack?: %sFrame[];\n  causes?: %sCause[];\n}\n\n", g.name, g.name)
+}
+
+// lowerFirst lowers the first word of a type name, e.g., "APIError" into "apiError".
+func lowerFirst(s string) string {
+	r := []rune(s)
+	n := 0
+	for n < len(r) && unicode.IsUpper(r[n]) {
+		n++
+	}
+	if n > 1 && n < len(r) && unicode.IsLower(r[n]) {
+		n-- // the last upper case letter starts the next word
+	}
+	for i := range n {
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+func quote(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
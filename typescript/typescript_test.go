@@ -0,0 +1,168 @@
+package typescript_test
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/typescript"
+)
+
+func TestGenerator_WriteTo(t *testing.T) {
+	userID, _ := errdef.DefineField[string]("user_id")
+	pin, _ := errdef.DefineField[int]("pin", errdef.Sensitive())
+	notFound := errdef.Define("not_found", errdef.HTTPStatus(404))
+	conflict := errdef.Define("conflict")
+
+	g := typescript.New(
+		[]errdef.Definition{notFound, conflict},
+		typescript.WithFields(pin.Key()),
+		typescript.WithKindFields(notFound, userID.Key()),
+		typescript.WithName("APIError"),
+	)
+
+	var b strings.Builder
+	n, err := g.WriteTo(&b)
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+	if int(n) != b.Len() {
+		t.Errorf("want %d bytes written, got %d", b.Len(), n)
+	}
+
+	want := `// Code generated by errdef/typescript. DO NOT EDIT.
+
+/** A frame of the stack trace of an error. */
+export interface APIErrorFrame {
+  func: string;
+  file: string;
+  line: number;
+}
+
+/** A cause of an error: an errdef error, or another error with its Go type. */
+export interface APIErrorCause {
+  message: string;
+  kind?: string;
+  type?: string;
+  fields?: Record<string, unknown>;
+  stack?: APIErrorFrame[];
+  causes?: APIErrorCause[];
+}
+
+/** An error of kind "not_found". HTTP status 404. */
+export interface NotFoundError {
+  message: string;
+  kind: "not_found";
+  fields: {
+    /** @default 404 */
+    http_status: number;
+    user_id?: string;
+    pin?: number | "[REDACTED]";
+  };
+  stack?: APIErrorFrame[];
+  causes?: APIErrorCause[];
+}
+
+/** An error of kind "conflict". */
+export interface ConflictError {
+  message: string;
+  kind: "conflict";
+  fields?: {
+    pin?: number | "[REDACTED]";
+  };
+  stack?: APIErrorFrame[];
+  causes?: APIErrorCause[];
+}
+
+/** An error of any of the kinds. */
+export type APIError = NotFoundError | ConflictError;
+
+/** The kinds of APIError. */
+export const apiErrorKinds = ["not_found", "conflict"] as const;
+
+export type APIErrorKind = APIError["kind"];
+
+/** Reports whether e is a serialized error of any of the kinds. */
+export function isAPIError(e: unknown): e is APIError {
+  return (
+    typeof e === "object" &&
+    e !== null &&
+    (apiErrorKinds as readonly unknown[]).includes((e as { kind?: unknown }).kind)
+  );
+}
+
+/** Reports whether e is a serialized error of kind "not_found". */
+export function isNotFoundError(e: unknown): e is NotFoundError {
+  return isAPIError(e) && e.kind === "not_found";
+}
+
+/** Reports whether e is a serialized error of kind "conflict". */
+export function isConflictError(e: unknown): e is ConflictError {
+  return isAPIError(e) && e.kind === "conflict";
+}
+`
+	if got := b.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+	if got := g.TypeName(conflict); got != "ConflictError" {
+		t.Errorf("want type name %q, got %q", "ConflictError", got)
+	}
+}
+
+func TestGenerator_FieldTypes(t *testing.T) {
+	type (
+		inner struct {
+			Name string `json:"name"`
+		}
+		payload struct {
+			ID       int               `json:"id"`
+			Tags     []string          `json:"tags,omitempty"`
+			Inner    *inner            `json:"inner"`
+			Labels   map[string]string `json:"labels"`
+			Data     []byte            `json:"data"`
+			Weird    []*int            `json:"weird-name"`
+			Ignored  string            `json:"-"`
+			internal string
+		}
+	)
+	ratio, _ := errdef.DefineField[float64]("ratio")
+	at, _ := errdef.DefineField[time.Time]("at")
+	secret, _ := errdef.DefineField[errdef.Redacted[string]]("secret")
+	body, _ := errdef.DefineField[payload]("body")
+	anything, _ := errdef.DefineField[any]("anything")
+
+	def := errdef.Define("typed", errdef.LogLevel(slog.LevelWarn), errdef.RetryAfter(time.Second))
+	var b strings.Builder
+	if _, err := typescript.New([]errdef.Definition{def},
+		typescript.WithFields(ratio.Key(), at.Key(), secret.Key(), body.Key(), anything.Key()),
+	).WriteTo(&b); err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	want := `  fields: {
+    /** @default "WARN" */
+    log_level: "DEBUG" | "INFO" | "WARN" | "ERROR" | (string & {});
+    /** @default 1000000000 */
+    retry_after: number;
+    ratio?: number;
+    at?: string;
+    secret?: string;
+    body?: {
+      id: number;
+      tags?: string[];
+      inner: {
+        name: string;
+      } | null;
+      labels: Record<string, string>;
+      data: string;
+      "weird-name": (number | null)[];
+    };
+    anything?: unknown;
+  };
+`
+	if got := b.String(); !strings.Contains(got, want) {
+		t.Errorf("want output containing:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/cbor"
+	"github.com/shiwano/errdef/marshaler"
+	"github.com/shiwano/errdef/msgpack"
 	"github.com/shiwano/errdef/resolver"
 	"github.com/shiwano/errdef/unmarshaler"
 )
@@ -16,6 +19,17 @@ var (
 	benchResolver              = resolver.New(benchDef)
 	benchUnmarshaler           = unmarshaler.NewJSON(benchResolver)
 	benchUnmarshalerWithStdlib = unmarshaler.NewJSON(benchResolver, unmarshaler.WithStandardSentinelErrors())
+
+	benchCount, _         = errdef.DefineField[int]("bench_count")
+	benchFormatMarshalers = []struct {
+		name        string
+		marshaler   *marshaler.Marshaler[[]byte]
+		unmarshaler *unmarshaler.Unmarshaler[[]byte]
+	}{
+		{"JSON", marshaler.NewJSON(), unmarshaler.NewJSON(benchResolver)},
+		{"MessagePack", msgpack.NewMarshaler(), msgpack.NewUnmarshaler(benchResolver)},
+		{"CBOR", cbor.NewMarshaler(), cbor.NewUnmarshaler(benchResolver)},
+	}
 )
 
 // unmarshaler: Unmarshal simple error
@@ -102,3 +116,41 @@ func BenchmarkUnmarshalerRoundTripDeepChain(b *testing.B) {
 		_, _ = benchUnmarshaler.Unmarshal(data)
 	}
 }
+
+func newBenchFormatError() errdef.Error {
+	err := benchDef.WithOptions(benchField("test_value"), benchCount(42)).New("level 1")
+	for range 2 {
+		err = benchDef.Wrap(err)
+	}
+	return err.(errdef.Error)
+}
+
+// unmarshaler: Unmarshal error with fields and chain (3 levels) per format
+func BenchmarkUnmarshalerUnmarshalFormats(b *testing.B) {
+	err := newBenchFormatError()
+	for _, f := range benchFormatMarshalers {
+		b.Run(f.name, func(b *testing.B) {
+			data, _ := f.marshaler.Marshal(err)
+			b.ResetTimer()
+			b.ReportAllocs()
+			for b.Loop() {
+				_, _ = f.unmarshaler.Unmarshal(data)
+			}
+			b.ReportMetric(float64(len(data)), "encoded-bytes")
+		})
+	}
+}
+
+// unmarshaler: Round-trip (Marshal + Unmarshal) per format
+func BenchmarkUnmarshalerRoundTripFormats(b *testing.B) {
+	err := newBenchFormatError()
+	for _, f := range benchFormatMarshalers {
+		b.Run(f.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				data, _ := f.marshaler.Marshal(err)
+				_, _ = f.unmarshaler.Unmarshal(data)
+			}
+		})
+	}
+}
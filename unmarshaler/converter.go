@@ -1,15 +1,22 @@
 package unmarshaler
 
 import (
+	"encoding"
 	"encoding/json"
 	"math"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/shiwano/errdef"
 )
 
-func tryConvertFieldValue(fk errdef.FieldKey, value any) (errdef.FieldValue, bool, error) {
+var durationType = reflect.TypeFor[time.Duration]()
+
+// tryConvertFieldValue converts value into a value of fk. Strings are parsed
+// into other types only if parseStrings is true, for the text format of %+v
+// that has no type information.
+func tryConvertFieldValue(fk errdef.FieldKey, value any, parseStrings bool) (errdef.FieldValue, bool, error) {
 	if v, ok := fk.NewValue(value); ok {
 		return v, true, nil
 	}
@@ -38,6 +45,15 @@ func tryConvertFieldValue(fk errdef.FieldKey, value any) (errdef.FieldValue, boo
 		} else if ok {
 			return v, true, nil
 		}
+	case string:
+		if !parseStrings {
+			break
+		}
+		if v, ok, err := tryConvertString(fk, tv, targetType); err != nil {
+			return nil, false, err
+		} else if ok {
+			return v, true, nil
+		}
 	}
 
 	valueType := reflect.TypeOf(value)
@@ -204,6 +220,57 @@ func tryConvertInt64(fk errdef.FieldKey, i64 int64, targetType reflect.Type) (er
 	return nil, false, nil
 }
 
+func tryConvertString(fk errdef.FieldKey, str string, targetType reflect.Type) (errdef.FieldValue, bool, error) {
+	targetPtr := reflect.New(targetType)
+
+	if u, ok := targetPtr.Interface().(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(str)); err != nil {
+			return nil, false, nil
+		}
+		v, ok := fk.NewValue(targetPtr.Elem().Interface())
+		return v, ok, nil
+	}
+
+	target := targetPtr.Elem()
+	switch kind := targetType.Kind(); {
+	case targetType == durationType:
+		d, err := time.ParseDuration(str)
+		if err != nil {
+			return nil, false, nil
+		}
+		target.SetInt(int64(d))
+	case kind == reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, false, nil
+		}
+		target.SetBool(b)
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		i, err := strconv.ParseInt(str, 10, targetType.Bits())
+		if err != nil {
+			return nil, false, nil
+		}
+		target.SetInt(i)
+	case kind >= reflect.Uint && kind <= reflect.Uint64:
+		u, err := strconv.ParseUint(str, 10, targetType.Bits())
+		if err != nil {
+			return nil, false, nil
+		}
+		target.SetUint(u)
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		f, err := strconv.ParseFloat(str, targetType.Bits())
+		if err != nil {
+			return nil, false, nil
+		}
+		target.SetFloat(f)
+	default:
+		return nil, false, nil
+	}
+
+	v, ok := fk.NewValue(target.Interface())
+	return v, ok, nil
+}
+
 func tryConvertViaJSON(fk errdef.FieldKey, value any, targetType reflect.Type) (errdef.FieldValue, bool, error) {
 	kind := targetType.Kind()
 
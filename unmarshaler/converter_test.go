@@ -2,9 +2,11 @@ package unmarshaler_test
 
 import (
 	"encoding/json"
+	"log/slog"
 	"maps"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/shiwano/errdef"
 	"github.com/shiwano/errdef/resolver"
@@ -351,6 +353,89 @@ func TestTryConvertInt64(t *testing.T) {
 	}
 }
 
+func TestTryConvertString(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name  string
+		def   errdef.Definition
+		input string
+		want  any
+	}{
+		{"int valid", defineWithTestField[int](), "42", 42},
+		{"int8 overflow", defineWithTestField[int8](), "128", nil},
+		{"int invalid", defineWithTestField[int](), "abc", nil},
+		{"uint valid", defineWithTestField[uint64](), "18446744073709551615", uint64(18446744073709551615)},
+		{"uint negative", defineWithTestField[uint](), "-1", nil},
+		{"float64 valid", defineWithTestField[float64](), "1.5", 1.5},
+		{"bool valid", defineWithTestField[bool](), "true", true},
+		{"duration valid", defineWithTestField[time.Duration](), "1m30s", 90 * time.Second},
+		{"duration invalid", defineWithTestField[time.Duration](), "90", nil},
+		{"text unmarshaler", defineWithTestField[slog.Level](), "WARN", slog.LevelWarn},
+		{"time valid", defineWithTestField[time.Time](), now.Format(time.RFC3339), now},
+		{"time invalid", defineWithTestField[time.Time](), "yesterday", nil},
+		{"struct unsupported", defineWithTestField[struct{ A int }](), "{1}", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := unmarshaler.NewText(resolver.New(tt.def))
+
+			unmarshaled, err := u.Unmarshal("test\n---\nkind: test_error\nfields:\n  test: " + tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			if tt.want == nil {
+				if val := maps.Collect(unmarshaled.UnknownFields())["test"]; val != tt.input {
+					t.Errorf("want original value %q in unknownFields, got %v", tt.input, val)
+				}
+				return
+			}
+			val, ok := unmarshaled.Fields().Get(unmarshaled.Fields().FindKeys("test")[0])
+			if !ok || !reflect.DeepEqual(val.Value(), tt.want) {
+				t.Errorf("want %v (%T), got %v (ok=%v)", tt.want, tt.want, val, ok)
+			}
+		})
+	}
+}
+
+func TestTryConvertString_NotForOtherDecoders(t *testing.T) {
+	tests := []struct {
+		name  string
+		def   errdef.Definition
+		input string
+	}{
+		{"int", defineWithTestField[int](), "404"},
+		{"bool", defineWithTestField[bool](), "true"},
+		{"duration", defineWithTestField[time.Duration](), "1m30s"},
+		{"text unmarshaler", defineWithTestField[slog.Level](), "WARN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := unmarshaler.NewJSON(resolver.New(tt.def))
+
+			unmarshaled, err := u.Unmarshal([]byte(`{"message":"test","kind":"test_error","fields":{"test":"` + tt.input + `"}}`))
+			if err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			if val := maps.Collect(unmarshaled.UnknownFields())["test"]; val != tt.input {
+				t.Errorf("want original value %q in unknownFields, got %v", tt.input, val)
+			}
+			if val, ok := unmarshaled.Fields().Get(tt.def.Fields().FindKeys("test")[0]); ok {
+				t.Errorf("want string not to be converted, got %v", val)
+			}
+		})
+	}
+}
+
+func defineWithTestField[T any]() errdef.Definition {
+	ctor, _ := errdef.DefineField[T]("test")
+	var zero T
+	return errdef.Define("test_error", ctor(zero))
+}
+
 func TestTryConvertMapToStruct(t *testing.T) {
 	type Address struct {
 		Street string `json:"street"`
@@ -65,10 +65,16 @@ type (
 		// 4. Complex types (tryConvertViaJSON): For map[string]any or []any:
 		//    - Converts to struct/map/slice types via JSON marshaling/unmarshaling
 		//
-		// 5. Underlying type conversion (tryConvertByUnderlyingType):
+		// 5. String parsing (tryConvertString): Only for Unmarshalers created by NewText,
+		//    when the value is string decoded from the text format of %+v:
+		//    - For types implementing encoding.TextUnmarshaler: Uses UnmarshalText
+		//    - For time.Duration: Parses with time.ParseDuration
+		//    - For bool, integer, and float types: Parses with strconv, checking range
+		//
+		// 6. Underlying type conversion (tryConvertByUnderlyingType):
 		//    - For derived types (e.g., type UserID string), converts if underlying types match
 		//
-		// 6. Pointer conversion (tryConvertPointer):
+		// 7. Pointer conversion (tryConvertPointer):
 		//    - For pointer types to primitives, creates pointer and converts the underlying value
 		//
 		// If conversion fails at all steps, the field is stored in UnknownFields with its original
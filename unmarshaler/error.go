@@ -40,6 +40,7 @@ type (
 		unknownFields map[string]any
 		stack         stack
 		causes        []error
+		parseStrings  bool
 	}
 )
 
@@ -67,6 +68,7 @@ func (e *unmarshaledError) Fields() errdef.Fields {
 	return &fields{
 		fields:        e.fields,
 		unknownFields: e.unknownFields,
+		parseStrings:  e.parseStrings,
 	}
 }
 
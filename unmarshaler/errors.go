@@ -9,6 +9,10 @@ var (
 	ErrUnknownKind = errdef.Define("errdef/unmarshaler.unknown_kind", errdef.NoTrace())
 	// ErrUnknownField is returned when an unknown field is encountered in strict mode.
 	ErrUnknownField = errdef.Define("errdef/unmarshaler.unknown_field", errdef.NoTrace())
+	// ErrInvalidRecord is yielded by UnmarshalStream for a record that cannot be
+	// unmarshaled. It wraps the underlying error, and LineFromError and
+	// OffsetFromError report where the record starts.
+	ErrInvalidRecord = errdef.Define("errdef/unmarshaler.invalid_record", errdef.NoTrace())
 	// ErrInternal is returned when an unexpected error occurs within the unmarshaler.
 	ErrInternal = errdef.Define("errdef/unmarshaler.internal", errdef.NoTrace())
 
@@ -16,4 +20,8 @@ var (
 	kindField, KindFromError = errdef.DefineField[errdef.Kind]("kind")
 	// FieldNameFromError extracts the field name from errors.
 	fieldNameField, FieldNameFromError = errdef.DefineField[string]("field_name")
+	// LineFromError extracts the 1-based line number of the record from errors.
+	lineField, LineFromError = errdef.DefineField[int]("line")
+	// OffsetFromError extracts the byte offset of the record from errors.
+	offsetField, OffsetFromError = errdef.DefineField[int64]("offset")
 )
@@ -14,6 +14,7 @@ type (
 	fields struct {
 		fields        map[errdef.FieldKey]errdef.FieldValue
 		unknownFields map[string]any
+		parseStrings  bool
 	}
 
 	unmarshaledFieldKey string
@@ -38,7 +39,10 @@ func (f *fields) Get(key errdef.FieldKey) (errdef.FieldValue, bool) {
 	if v, ok := f.unknownFields[key.String()]; ok {
 		if _, ok := key.(unmarshaledFieldKey); ok {
 			return &unmarshaledFieldValue{value: v}, true
-		} else if tv, ok, err := tryConvertFieldValue(key, v); ok && err == nil {
+		} else if str, ok := v.(string); ok && isRedactedString(str) {
+			// Redacted values must not be converted into typed fields.
+			return nil, false
+		} else if tv, ok, err := tryConvertFieldValue(key, v, f.parseStrings); ok && err == nil {
 			return tv, true
 		}
 	}
@@ -97,7 +101,7 @@ func (f *fields) IsZero() bool {
 func (f *fields) MarshalJSON() ([]byte, error) {
 	result := make(map[string]any)
 	for k, v := range f.All() {
-		result[k.String()] = v.Value()
+		result[k.String()] = errdef.PresentFieldValue(k, v)
 	}
 	return json.Marshal(result)
 }
@@ -105,7 +109,7 @@ func (f *fields) MarshalJSON() ([]byte, error) {
 func (f *fields) LogValue() slog.Value {
 	attrs := make([]slog.Attr, 0, f.Len())
 	for k, v := range f.All() {
-		attrs = append(attrs, slog.Any(k.String(), v.Value()))
+		attrs = append(attrs, slog.Any(k.String(), errdef.PresentFieldValue(k, v)))
 	}
 	return slog.GroupValue(attrs...)
 }
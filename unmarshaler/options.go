@@ -48,11 +48,33 @@ func WithCustomFields(keys ...errdef.FieldKey) Option {
 	}
 }
 
+// WithStreamPath returns an Option that makes UnmarshalStream extract the
+// error data from the given path of object keys in each record, instead of
+// the record itself.
+//
+// For example, WithStreamPath("error") reads the "error" attribute of the
+// output of slog.JSONHandler. Records that do not have the path are skipped
+// without errors, since logs usually contain records without errors.
+func WithStreamPath(keys ...string) Option {
+	return func(u *unmarshaler) {
+		u.streamPath = keys
+	}
+}
+
+// WithMaxRecordSize returns an Option that sets the maximum size in bytes of
+// a record read by UnmarshalStream. Larger records are skipped with
+// ErrInvalidRecord. The default is 1 MiB.
+func WithMaxRecordSize(n int) Option {
+	return func(u *unmarshaler) {
+		u.maxRecordSize = n
+	}
+}
+
 // WithBuiltinFields returns an Option that registers all built-in field keys
 // from the errdef package to be recognized during unmarshaling.
 //
 // This includes: http_status, log_level, trace_id, domain, user_hint, public,
-// retryable, retry_after, unreportable, exit_code, help_url.
+// retryable, retry_after, unreportable, bug, exit_code, help_url.
 //
 // This is a convenience function that calls WithCustomFields with all
 // built-in field keys. When unmarshaling errors with built-in fields, these
@@ -69,6 +91,7 @@ func WithBuiltinFields() Option {
 		errdef.Retryable.Key(),
 		errdef.RetryAfter.Key(),
 		errdef.Unreportable.Key(),
+		errdef.Bug.Key(),
 		errdef.ExitCode.Key(),
 		errdef.HelpURL.Key(),
 		errdef.Details{}.Key(),
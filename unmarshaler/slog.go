@@ -0,0 +1,68 @@
+package unmarshaler
+
+import (
+	"encoding/json"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/resolver"
+)
+
+// slogData is the JSON shape of the slog values of errors: the value of
+// errdef.Presenter.MakeErrorLogValue, which has the origin frame instead of
+// the stack, and the value of errdef.Node.LogValue, which has the stack and
+// the causes.
+type slogData struct {
+	Message string         `json:"message"`
+	Kind    errdef.Kind    `json:"kind,omitempty"`
+	Type    string         `json:"type,omitempty"`
+	Fields  map[string]any `json:"fields,omitempty"`
+	Origin  *errdef.Frame  `json:"origin,omitempty"`
+	Stack   []errdef.Frame `json:"stack,omitempty"`
+	Causes  []*slogData    `json:"causes,omitempty"`
+}
+
+// NewSlogJSON creates a new Unmarshaler that unmarshals errors logged by
+// slog.JSONHandler, decoding the value of the error attribute with
+// DecodeSlogJSON.
+//
+// To read log files, use it with UnmarshalStream and WithStreamPath:
+//
+//	u := unmarshaler.NewSlogJSON(r, unmarshaler.WithStreamPath("error"))
+//	for restored, err := range u.UnmarshalStream(file) { ... }
+func NewSlogJSON(resolver resolver.Resolver, opts ...Option) *Unmarshaler[[]byte] {
+	return New(resolver, DecodeSlogJSON, opts...)
+}
+
+// DecodeSlogJSON decodes the JSON of the slog value of an error into
+// DecodedData.
+//
+// It accepts the shape of the default slog value of errors (message, kind,
+// fields, and the origin frame) and that of errdef.Node (message, kind,
+// fields, stack, and causes), so the JSON encoding of DecodedData is accepted
+// as well. The origin frame becomes a stack with a single frame.
+func DecodeSlogJSON(data []byte) (*DecodedData, error) {
+	var d slogData
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	return d.decodedData(), nil
+}
+
+func (d *slogData) decodedData() *DecodedData {
+	decoded := &DecodedData{
+		Message: d.Message,
+		Kind:    d.Kind,
+		Type:    d.Type,
+		Fields:  d.Fields,
+		Stack:   d.Stack,
+	}
+	if decoded.Stack == nil && d.Origin != nil {
+		decoded.Stack = []errdef.Frame{*d.Origin}
+	}
+	for _, c := range d.Causes {
+		if c != nil {
+			decoded.Causes = append(decoded.Causes, c.decodedData())
+		}
+	}
+	return decoded
+}
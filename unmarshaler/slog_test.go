@@ -0,0 +1,127 @@
+package unmarshaler_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/resolver"
+	"github.com/shiwano/errdef/unmarshaler"
+)
+
+func TestNewSlogJSON(t *testing.T) {
+	errOuter := errdef.Define("outer")
+	errInner := errdef.Define("inner")
+	userID, userIDFrom := errdef.DefineField[int]("user_id")
+	timeout, timeoutFrom := errdef.DefineField[time.Duration]("timeout")
+	r := resolver.New(errOuter, errInner)
+
+	logError := func(t *testing.T, v any) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		slog.New(slog.NewJSONHandler(&buf, nil)).Error("failed", "error", v)
+		return buf.Bytes()
+	}
+
+	t.Run("default log value", func(t *testing.T) {
+		orig := errOuter.WithOptions(userID(42), timeout(3*time.Second)).New("failed").(errdef.Error)
+		u := unmarshaler.NewSlogJSON(r,
+			unmarshaler.WithStreamPath("error"),
+			unmarshaler.WithCustomFields(userID.Key(), timeout.Key()),
+		)
+
+		var restored []unmarshaler.UnmarshaledError
+		for e, err := range u.UnmarshalStream(bytes.NewReader(logError(t, orig))) {
+			if err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			restored = append(restored, e)
+		}
+		if len(restored) != 1 {
+			t.Fatalf("want 1 error, got %d", len(restored))
+		}
+		got := restored[0]
+
+		if got.Error() != "failed" {
+			t.Errorf("want message %q, got %q", "failed", got.Error())
+		}
+		if got.Kind() != "outer" {
+			t.Errorf("want kind %q, got %q", "outer", got.Kind())
+		}
+		if v, ok := userIDFrom(got); !ok || v != 42 {
+			t.Errorf("want user_id 42, got %v (ok=%v)", v, ok)
+		}
+		if v, ok := timeoutFrom(got); !ok || v != 3*time.Second {
+			t.Errorf("want timeout 3s, got %v (ok=%v)", v, ok)
+		}
+		origin, _ := orig.Stack().HeadFrame()
+		if frames := got.Stack().Frames(); !reflect.DeepEqual(frames, []errdef.Frame{origin}) {
+			t.Errorf("want origin frame %v, got %v", origin, frames)
+		}
+	})
+
+	t.Run("node log value", func(t *testing.T) {
+		orig := errOuter.Wrapf(errInner.Wrap(io.EOF), "outer").(errdef.Error)
+		node := &errdef.Node{Error: orig, Causes: orig.UnwrapTree()}
+		u := unmarshaler.NewSlogJSON(r,
+			unmarshaler.WithStreamPath("error"),
+			unmarshaler.WithStandardSentinelErrors(),
+		)
+
+		var got unmarshaler.UnmarshaledError
+		for e, err := range u.UnmarshalStream(bytes.NewReader(logError(t, node))) {
+			if err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			got = e
+		}
+		if got == nil {
+			t.Fatal("want an error")
+		}
+
+		if got.Kind() != "outer" {
+			t.Errorf("want kind %q, got %q", "outer", got.Kind())
+		}
+		if !reflect.DeepEqual(got.Stack().Frames(), orig.Stack().Frames()) {
+			t.Errorf("want stack %v, got %v", orig.Stack().Frames(), got.Stack().Frames())
+		}
+		var inner errdef.Error
+		if !errors.As(got.Unwrap()[0], &inner) || inner.Kind() != "inner" {
+			t.Errorf("want inner cause, got %v", got.Unwrap())
+		}
+	})
+}
+
+func TestDecodeSlogJSON(t *testing.T) {
+	data := []byte(`{
+		"message": "m",
+		"kind": "k",
+		"origin": {"func": "f", "file": "a.go", "line": 1},
+		"causes": [{"message": "c", "causes": [{"message": "d"}]}]
+	}`)
+	decoded, err := unmarshaler.DecodeSlogJSON(data)
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	want := &unmarshaler.DecodedData{
+		Message: "m",
+		Kind:    "k",
+		Stack:   []errdef.Frame{{Func: "f", File: "a.go", Line: 1}},
+		Causes: []*unmarshaler.DecodedData{
+			{Message: "c", Causes: []*unmarshaler.DecodedData{{Message: "d"}}},
+		},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Errorf("want %+v, got %+v", want, decoded)
+	}
+
+	if _, err := unmarshaler.DecodeSlogJSON([]byte(`[]`)); err == nil {
+		t.Error("want error for non-object data")
+	}
+}
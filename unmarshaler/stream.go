@@ -0,0 +1,227 @@
+package unmarshaler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+)
+
+type (
+	// recordScanner splits a stream into JSON records, which are objects or
+	// arrays separated by optional whitespace, without holding more than one
+	// record in memory.
+	recordScanner struct {
+		r       *bufio.Reader
+		pending []byte // bytes pushed back to be read before r
+		buf     []byte
+		maxSize int
+		line    int
+		offset  int64
+	}
+
+	record struct {
+		data   []byte
+		line   int
+		offset int64
+		err    error
+	}
+)
+
+const defaultMaxRecordSize = 1 << 20
+
+// UnmarshalStream reads a stream of JSON records, such as JSON Lines or
+// concatenated JSON objects, and yields the unmarshaled error of each record.
+//
+// The error data is taken from the path set by WithStreamPath, or from the
+// record itself. It is passed to the decoder of an Unmarshaler[[]byte] (e.g.,
+// one created by NewJSON), and decoded as the JSON encoding of DecodedData for
+// other input types.
+//
+// A record that cannot be unmarshaled is skipped, yielding ErrInvalidRecord
+// with the position of the record. If the record is malformed (e.g., a
+// truncated line), reading resumes at the line after the one it starts on.
+// Only one record is held in memory at a time, up to the size set by
+// WithMaxRecordSize. An error from r is yielded as ErrDecodeFailure and ends
+// the stream.
+func (d *Unmarshaler[T]) UnmarshalStream(r io.Reader) iter.Seq2[UnmarshaledError, error] {
+	return func(yield func(UnmarshaledError, error) bool) {
+		s := &recordScanner{r: bufio.NewReader(r), maxSize: d.maxRecordSize, line: 1}
+		for {
+			rec, err := s.next()
+			if err != nil {
+				if err != io.EOF {
+					yield(nil, ErrDecodeFailure.Wrap(err))
+				}
+				return
+			}
+
+			var unmarshaled UnmarshaledError
+			err = rec.err
+			if err == nil {
+				var ok bool
+				if unmarshaled, ok, err = d.unmarshalRecord(rec.data); err == nil && !ok {
+					continue
+				}
+			}
+			if err != nil {
+				err = ErrInvalidRecord.WithOptions(
+					lineField(rec.line),
+					offsetField(rec.offset),
+				).Wrapf(err, "line %d", rec.line)
+			}
+			if !yield(unmarshaled, err) {
+				return
+			}
+		}
+	}
+}
+
+// unmarshalRecord unmarshals the error data in the stream path of a record.
+// It returns false if the record does not have the path.
+func (d *Unmarshaler[T]) unmarshalRecord(data []byte) (UnmarshaledError, bool, error) {
+	for i, key := range d.streamPath {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			if i > 0 {
+				var typeErr *json.UnmarshalTypeError
+				if errors.As(err, &typeErr) {
+					return nil, false, nil
+				}
+			}
+			return nil, false, err
+		}
+		if data = obj[key]; data == nil || string(data) == "null" {
+			return nil, false, nil
+		}
+	}
+
+	var decoded *DecodedData
+	var err error
+	if decoder, ok := any(d.decoder).(Decoder[[]byte]); ok {
+		decoded, err = decoder(data)
+	} else {
+		decoded, err = jsonToDecodedData(data)
+	}
+	if err != nil {
+		return nil, false, ErrDecodeFailure.Wrap(err)
+	}
+
+	unmarshaled, err := d.unmarshal(decoded)
+	if err != nil {
+		return nil, false, err
+	}
+	return unmarshaled, true, nil
+}
+
+// next reads the next record. A malformed record is returned with its error,
+// after the rest of the line it starts on is skipped.
+// It returns io.EOF at the end of the stream.
+func (s *recordScanner) next() (record, error) {
+	c, err := s.skipSpace()
+	if err != nil {
+		return record{}, err
+	}
+	rec := record{line: s.line, offset: s.offset - 1}
+	s.buf = append(s.buf[:0], c)
+
+	if c != '{' && c != '[' {
+		rec.err = fmt.Errorf("invalid character %q looking for beginning of record", c)
+		return rec, s.skipLine(s.buf)
+	}
+
+	depth, inString, escaped := 1, false, false
+	for depth > 0 {
+		c, err := s.readByte()
+		if err == io.EOF {
+			rec.err = io.ErrUnexpectedEOF
+			return rec, s.skipLine(s.buf)
+		}
+		if err != nil {
+			return record{}, err
+		}
+		if s.buf = append(s.buf, c); len(s.buf) > s.maxSize {
+			rec.err = fmt.Errorf("record exceeds %d bytes", s.maxSize)
+			return rec, s.skipLine(s.buf)
+		}
+
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			case c == '\n':
+				rec.err = errors.New("unterminated string")
+				return rec, nil
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	rec.data = s.buf
+	return rec, nil
+}
+
+// skipLine skips the rest of the line on which the data that has been read
+// starts, pushing back the data after it to be read again.
+func (s *recordScanner) skipLine(data []byte) error {
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		rest := data[i+1:]
+		s.pending = append(slices.Clone(rest), s.pending...)
+		s.offset -= int64(len(rest))
+		s.line -= bytes.Count(rest, []byte{'\n'})
+		return nil
+	}
+	for {
+		c, err := s.readByte()
+		if err == io.EOF || c == '\n' {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *recordScanner) skipSpace() (byte, error) {
+	for {
+		c, err := s.readByte()
+		if err != nil {
+			return 0, err
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n':
+		default:
+			return c, nil
+		}
+	}
+}
+
+func (s *recordScanner) readByte() (byte, error) {
+	var c byte
+	if len(s.pending) > 0 {
+		c, s.pending = s.pending[0], s.pending[1:]
+	} else {
+		var err error
+		if c, err = s.r.ReadByte(); err != nil {
+			return 0, err
+		}
+	}
+	s.offset++
+	if c == '\n' {
+		s.line++
+	}
+	return c, nil
+}
@@ -0,0 +1,176 @@
+package unmarshaler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/resolver"
+	"github.com/shiwano/errdef/unmarshaler"
+)
+
+type streamResult struct {
+	msg    string
+	line   int
+	offset int64
+}
+
+func collectStream(t *testing.T, u *unmarshaler.Unmarshaler[[]byte], input string) []streamResult {
+	t.Helper()
+	var results []streamResult
+	for e, err := range u.UnmarshalStream(strings.NewReader(input)) {
+		if err != nil {
+			if !errors.Is(err, unmarshaler.ErrInvalidRecord) {
+				t.Fatalf("want ErrInvalidRecord, got %v", err)
+			}
+			results = append(results, streamResult{
+				msg:    err.Error(),
+				line:   unmarshaler.LineFromError.OrZero(err),
+				offset: unmarshaler.OffsetFromError.OrZero(err),
+			})
+			continue
+		}
+		results = append(results, streamResult{msg: e.Error()})
+	}
+	return results
+}
+
+func TestUnmarshaler_UnmarshalStream(t *testing.T) {
+	def := errdef.Define("test_error")
+	r := resolver.New(def)
+
+	t.Run("json lines", func(t *testing.T) {
+		u := unmarshaler.NewJSON(r)
+		var lines []string
+		for _, msg := range []string{"first", "second", "third"} {
+			data, err := json.Marshal(def.New(msg))
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			lines = append(lines, string(data))
+		}
+
+		results := collectStream(t, u, strings.Join(lines, "\n")+"\n")
+		if len(results) != 3 {
+			t.Fatalf("want 3 results, got %v", results)
+		}
+		for i, want := range []string{"first", "second", "third"} {
+			if results[i].msg != want {
+				t.Errorf("want message %q, got %q", want, results[i].msg)
+			}
+		}
+	})
+
+	t.Run("concatenated json", func(t *testing.T) {
+		u := unmarshaler.NewJSON(r)
+		input := `{"message":"a","kind":"test_error"}{"message":"b","kind":"test_error"}
+{
+  "message": "c {[\"",
+  "kind": "test_error"
+}`
+		results := collectStream(t, u, input)
+		want := []streamResult{{msg: "a"}, {msg: "b"}, {msg: `c {["`}}
+		if len(results) != len(want) {
+			t.Fatalf("want %v, got %v", want, results)
+		}
+		for i := range want {
+			if results[i] != want[i] {
+				t.Errorf("want %v, got %v", want[i], results[i])
+			}
+		}
+	})
+
+	t.Run("with stream path", func(t *testing.T) {
+		u := unmarshaler.NewJSON(r, unmarshaler.WithStreamPath("attrs", "error"))
+		input := `{"level":"INFO","msg":"no error"}
+{"level":"ERROR","attrs":{"error":{"message":"boom","kind":"test_error"}}}
+{"level":"ERROR","attrs":{"error":null}}
+{"level":"ERROR","attrs":"not an object"}
+`
+		results := collectStream(t, u, input)
+		if len(results) != 1 || results[0].msg != "boom" {
+			t.Errorf("want only boom, got %v", results)
+		}
+	})
+
+	t.Run("invalid records are skipped with positions", func(t *testing.T) {
+		u := unmarshaler.NewJSON(r)
+		input := `{"message":"a","kind":"test_error"}
+not json
+{"message":"truncated
+{"message":"b","kind":"test_error"}
+{"message":"c","kind":
+{"message":"d","kind":"test_error"}
+{"message":"e","kind":"unknown"}
+[1, 2]
+{"message":"f","kind":"test_error"}`
+		results := collectStream(t, u, input)
+		want := []streamResult{
+			{msg: "a"},
+			{msg: `line 2: invalid character 'n' looking for beginning of record`, line: 2, offset: 36},
+			{msg: "line 3: unterminated string", line: 3, offset: 45},
+			{msg: "b"},
+			{msg: "line 5: unexpected EOF", line: 5, offset: 103},
+			{msg: "d"},
+			{msg: "line 7: unknown kind", line: 7, offset: 162},
+			{msg: "line 8: json: cannot unmarshal array into Go value of type unmarshaler.DecodedData", line: 8, offset: 195},
+			{msg: "f"},
+		}
+		if len(results) != len(want) {
+			t.Fatalf("want %d results, got %v", len(want), results)
+		}
+		for i := range want {
+			if results[i] != want[i] {
+				t.Errorf("want %v, got %v", want[i], results[i])
+			}
+		}
+	})
+
+	t.Run("max record size", func(t *testing.T) {
+		u := unmarshaler.NewJSON(r, unmarshaler.WithMaxRecordSize(64))
+		input := `{"message":"` + strings.Repeat("x", 100) + `","kind":"test_error"}
+{"message":"small","kind":"test_error"}
+`
+		results := collectStream(t, u, input)
+		want := []streamResult{
+			{msg: "line 1: record exceeds 64 bytes", line: 1},
+			{msg: "small"},
+		}
+		if len(results) != len(want) {
+			t.Fatalf("want %v, got %v", want, results)
+		}
+		for i := range want {
+			if results[i] != want[i] {
+				t.Errorf("want %v, got %v", want[i], results[i])
+			}
+		}
+	})
+
+	t.Run("read error ends the stream", func(t *testing.T) {
+		u := unmarshaler.NewJSON(r)
+		readErr := errors.New("read failure")
+		var errs []error
+		for _, err := range u.UnmarshalStream(iotest.ErrReader(readErr)) {
+			errs = append(errs, err)
+		}
+		if len(errs) != 1 || !errors.Is(errs[0], unmarshaler.ErrDecodeFailure) || !errors.Is(errs[0], readErr) {
+			t.Errorf("want a single decode failure, got %v", errs)
+		}
+	})
+
+	t.Run("stops when yield returns false", func(t *testing.T) {
+		u := unmarshaler.NewJSON(r)
+		input := strings.Repeat(`{"message":"a","kind":"test_error"}`+"\n", 3)
+		count := 0
+		for range u.UnmarshalStream(strings.NewReader(input)) {
+			count++
+			break
+		}
+		if count != 1 {
+			t.Errorf("want 1 iteration, got %d", count)
+		}
+	})
+}
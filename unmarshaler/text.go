@@ -0,0 +1,278 @@
+package unmarshaler
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/resolver"
+)
+
+// textParser parses the detailed text format of errors, line by line.
+type textParser struct {
+	lines []string
+	pos   int
+}
+
+var (
+	textItemPattern   = regexp.MustCompile(`^\[\d+\](?: (.*))?$`)
+	textCausesPattern = regexp.MustCompile(`^causes: \(\d+ errors?\)$`)
+	textSourcePattern = regexp.MustCompile(`^[ >] +\d+:(?: |$)`)
+)
+
+// NewText creates a new Unmarshaler that unmarshals errors from the detailed
+// text format printed with %+v, decoding it with DecodeText.
+//
+// Unlike the other Unmarshalers, it parses string field values into the
+// types of the fields (e.g., "404" into an int field), since the text format
+// has no type information.
+func NewText(resolver resolver.Resolver, opts ...Option) *Unmarshaler[string] {
+	u := New(resolver, DecodeText, opts...)
+	u.parseStrings = true
+	return u
+}
+
+// DecodeText decodes the detailed text format printed with %+v into
+// DecodedData, so that errors pasted into bug reports can be restored.
+//
+// It parses the message, the kind, the fields (including multiline values
+// written with "|"), the stack, and the causes. Source code snippets of
+// errdef.StackSource are skipped. Uniform indentation added by pasting is
+// removed, and trailing whitespace is ignored.
+//
+// Since the format is for humans, some information cannot be restored: field
+// values are decoded as strings (which NewText parses into the types of the
+// fields), causes without a kind have no Type, and a message containing
+// a line that looks like a part of the format is cut there.
+func DecodeText(text string) (*DecodedData, error) {
+	p := &textParser{lines: dedentLines(text)}
+	for p.pos < len(p.lines) && p.lines[p.pos] == "" {
+		p.pos++
+	}
+	if p.pos == len(p.lines) {
+		return nil, fmt.Errorf("empty text")
+	}
+
+	first := p.lines[p.pos]
+	p.pos++
+	decoded, err := p.parseError(first, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected %q", p.lines[p.pos])
+	}
+	return decoded, nil
+}
+
+// parseError parses an error whose first message line has been read.
+// Its details are indented by indent, and itemIndents are the indents of
+// the cause items that may follow it, which end its message.
+func (p *textParser) parseError(first string, indent int, itemIndents []int) (*DecodedData, error) {
+	messageLines := []string{first}
+	for ; p.pos < len(p.lines); p.pos++ {
+		line := p.lines[p.pos]
+		if textLineAt(line, indent) == "---" || p.isItem(line, itemIndents) {
+			break
+		}
+		messageLines = append(messageLines, line)
+	}
+	decoded := &DecodedData{Message: strings.Join(messageLines, "\n")}
+
+	if p.pos == len(p.lines) || textLineAt(p.lines[p.pos], indent) != "---" {
+		return decoded, nil
+	}
+	p.pos++
+
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		text := textLineAt(line, indent)
+
+		var err error
+		switch {
+		case p.isItem(line, itemIndents):
+			return decoded, nil
+		case strings.HasPrefix(text, "kind: "):
+			decoded.Kind = errdef.Kind(strings.TrimPrefix(text, "kind: "))
+			p.pos++
+		case text == "fields:":
+			p.pos++
+			decoded.Fields, err = p.parseFields(indent + 2)
+		case text == "stack:":
+			p.pos++
+			decoded.Stack, err = p.parseStack(indent + 2)
+		case textCausesPattern.MatchString(text):
+			p.pos++
+			decoded.Causes, err = p.parseCauses(indent, itemIndents)
+		default:
+			return nil, p.errorf("unexpected %q", line)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return decoded, nil
+}
+
+func (p *textParser) parseFields(indent int) (map[string]any, error) {
+	fields := make(map[string]any)
+	for p.pos < len(p.lines) {
+		text := textLineAt(p.lines[p.pos], indent)
+		if text == "" {
+			break
+		}
+		name, value, ok := strings.Cut(text, ":")
+		if !ok {
+			return nil, p.errorf("want field, got %q", p.lines[p.pos])
+		}
+		value = strings.TrimPrefix(value, " ")
+		p.pos++
+
+		if value == "|" && p.pos < len(p.lines) && strings.HasPrefix(p.lines[p.pos], strings.Repeat(" ", indent+2)) {
+			value = p.parseMultiline(indent + 2)
+		}
+		fields[name] = value
+	}
+	if len(fields) == 0 {
+		return nil, p.errorf("want fields")
+	}
+	return fields, nil
+}
+
+// parseMultiline parses the lines of a multiline field value, which are
+// indented by indent and followed by a blank line.
+func (p *textParser) parseMultiline(indent int) string {
+	prefix := strings.Repeat(" ", indent)
+	var lines []string
+	for ; p.pos < len(p.lines); p.pos++ {
+		line := p.lines[p.pos]
+		if line != "" && !strings.HasPrefix(line, prefix) {
+			break
+		}
+		lines = append(lines, strings.TrimPrefix(line, prefix))
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (p *textParser) parseStack(indent int) ([]errdef.Frame, error) {
+	var frames []errdef.Frame
+	for p.pos < len(p.lines) {
+		fn := textLineAt(p.lines[p.pos], indent)
+		if fn == "" {
+			break
+		}
+		p.pos++
+
+		var location string
+		if p.pos < len(p.lines) {
+			location = textLineAt(p.lines[p.pos], indent+2)
+		}
+		i := strings.LastIndexByte(location, ':')
+		if i < 0 {
+			return nil, p.errorf("want file and line of %s", fn)
+		}
+		line, err := strconv.Atoi(location[i+1:])
+		if err != nil {
+			return nil, p.errorf("invalid line number in %q", location)
+		}
+		frames = append(frames, errdef.Frame{Func: fn, File: location[:i], Line: line})
+		p.pos++
+
+		prefix := strings.Repeat(" ", indent+2)
+		for p.pos < len(p.lines) {
+			line, ok := strings.CutPrefix(p.lines[p.pos], prefix)
+			if !ok || !textSourcePattern.MatchString(line) {
+				break
+			}
+			p.pos++
+		}
+	}
+	if len(frames) == 0 {
+		return nil, p.errorf("want stack frames")
+	}
+	return frames, nil
+}
+
+// parseCauses parses the cause items after a causes header indented by
+// indent. The items of the top-level error are indented by two more spaces.
+func (p *textParser) parseCauses(indent int, itemIndents []int) ([]*DecodedData, error) {
+	itemIndent := indent
+	if indent == 0 {
+		itemIndent = 2
+	}
+	itemIndents = append(itemIndents[:len(itemIndents):len(itemIndents)], itemIndent)
+
+	var causes []*DecodedData
+	for p.pos < len(p.lines) {
+		m := textItemPattern.FindStringSubmatch(textLineAt(p.lines[p.pos], itemIndent))
+		if m == nil {
+			break
+		}
+		p.pos++
+		cause, err := p.parseError(m[1], itemIndent+4, itemIndents)
+		if err != nil {
+			return nil, err
+		}
+		causes = append(causes, cause)
+	}
+	if len(causes) == 0 {
+		return nil, p.errorf("want causes")
+	}
+	return causes, nil
+}
+
+// isItem reports whether the line is a cause item at one of the indents.
+func (p *textParser) isItem(line string, indents []int) bool {
+	for _, indent := range indents {
+		if textItemPattern.MatchString(textLineAt(line, indent)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *textParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+// textLineAt returns the text of the line if it is indented by exactly
+// indent spaces, or "" otherwise.
+func textLineAt(line string, indent int) string {
+	if len(line) <= indent || strings.TrimLeft(line[:indent], " ") != "" || line[indent] == ' ' {
+		return ""
+	}
+	return line[indent:]
+}
+
+// dedentLines splits the text into lines without trailing whitespace and
+// removes the indentation common to all of them.
+func dedentLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(strings.TrimRight(text, " \t\n"), "\n")
+
+	common := -1
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		lines[i] = line
+		if line == "" {
+			continue
+		}
+		if n := len(line) - len(strings.TrimLeft(line, " ")); common < 0 || n < common {
+			common = n
+		}
+	}
+	if common <= 0 {
+		return lines
+	}
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = line[common:]
+		}
+	}
+	return lines
+}
@@ -0,0 +1,148 @@
+package unmarshaler_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/resolver"
+	"github.com/shiwano/errdef/unmarshaler"
+)
+
+func TestNewText(t *testing.T) {
+	errOuter := errdef.Define("outer", errdef.StackSource(2, 1))
+	errInner := errdef.Define("inner")
+	count, countFrom := errdef.DefineField[int]("count")
+	timeout, timeoutFrom := errdef.DefineField[time.Duration]("timeout")
+	query, queryFrom := errdef.DefineField[string]("query")
+	r := resolver.New(errOuter, errInner)
+
+	inner := errInner.WithOptions(query("SELECT *\nFROM users\n\n  WHERE id = 1")).Wrap(io.EOF)
+	joined := errors.Join(inner, errors.New("plain"))
+	orig := errOuter.WithOptions(count(3), timeout(2*time.Second)).
+		Wrapf(fmt.Errorf("wrapped: %w", joined), "outer").(errdef.Error)
+
+	u := unmarshaler.NewText(r, unmarshaler.WithCustomFields(count.Key(), timeout.Key(), query.Key()))
+	got, err := u.Unmarshal(fmt.Sprintf("%+v", orig))
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if got.Error() != orig.Error() {
+		t.Errorf("want message %q, got %q", orig.Error(), got.Error())
+	}
+	if got.Kind() != "outer" {
+		t.Errorf("want kind %q, got %q", "outer", got.Kind())
+	}
+	if v, ok := countFrom(got); !ok || v != 3 {
+		t.Errorf("want count 3, got %v (ok=%v)", v, ok)
+	}
+	if v, ok := timeoutFrom(got); !ok || v != 2*time.Second {
+		t.Errorf("want timeout 2s, got %v (ok=%v)", v, ok)
+	}
+	if !reflect.DeepEqual(got.Stack().Frames(), orig.Stack().Frames()) {
+		t.Errorf("want stack %v, got %v", orig.Stack().Frames(), got.Stack().Frames())
+	}
+
+	// outer -> wrapped -> joined -> [inner -> EOF, plain]
+	wrapped := got.Unwrap()
+	if len(wrapped) != 1 || wrapped[0].Error() != "wrapped: EOF\nplain" {
+		t.Fatalf("want wrapped cause, got %v", wrapped)
+	}
+	joinedCauses := wrapped[0].(interface{ Unwrap() []error }).Unwrap()
+	if len(joinedCauses) != 1 {
+		t.Fatalf("want joined cause, got %v", joinedCauses)
+	}
+	leaves := joinedCauses[0].(interface{ Unwrap() []error }).Unwrap()
+	if len(leaves) != 2 {
+		t.Fatalf("want 2 joined errors, got %v", leaves)
+	}
+
+	var restoredInner errdef.Error
+	if !errors.As(leaves[0], &restoredInner) || restoredInner.Kind() != "inner" {
+		t.Fatalf("want inner error, got %v", leaves[0])
+	}
+	if v, ok := queryFrom(restoredInner); !ok || v != "SELECT *\nFROM users\n\n  WHERE id = 1" {
+		t.Errorf("want multiline query, got %q (ok=%v)", v, ok)
+	}
+	if !reflect.DeepEqual(restoredInner.Stack().Frames(), inner.(errdef.Error).Stack().Frames()) {
+		t.Errorf("want inner stack %v, got %v", inner.(errdef.Error).Stack().Frames(), restoredInner.Stack().Frames())
+	}
+	if leaves[1].Error() != "plain" {
+		t.Errorf("want plain error, got %q", leaves[1].Error())
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	t.Run("message only", func(t *testing.T) {
+		decoded, err := unmarshaler.DecodeText("line 1\nline 2\n")
+		if err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		if want := (&unmarshaler.DecodedData{Message: "line 1\nline 2"}); !reflect.DeepEqual(decoded, want) {
+			t.Errorf("want %+v, got %+v", want, decoded)
+		}
+	})
+
+	t.Run("pasted with indentation and trailing spaces", func(t *testing.T) {
+		text := `
+    not found
+    ---
+    kind: not_found
+    fields:
+      empty:
+      note: |
+        a
+
+        b
+
+      user_id: u1
+    stack:
+      main.main
+        /app/main.go:10
+           9: 	x := 1
+        > 10: 	return err
+    causes: (1 error)
+      [1] EOF
+`
+		decoded, err := unmarshaler.DecodeText(text)
+		if err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		want := &unmarshaler.DecodedData{
+			Message: "not found",
+			Kind:    "not_found",
+			Fields:  map[string]any{"empty": "", "note": "a\n\nb", "user_id": "u1"},
+			Stack:   []errdef.Frame{{Func: "main.main", File: "/app/main.go", Line: 10}},
+			Causes:  []*unmarshaler.DecodedData{{Message: "EOF"}},
+		}
+		if !reflect.DeepEqual(decoded, want) {
+			t.Errorf("want %+v, got %+v", want, decoded)
+		}
+	})
+
+	errorTests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "empty", text: " \n", want: "empty text"},
+		{name: "unexpected section", text: "m\n---\nfoo: bar", want: `line 3: unexpected "foo: bar"`},
+		{name: "missing location", text: "m\n---\nstack:\n  main.main", want: "line 5: want file and line of main.main"},
+		{name: "invalid line number", text: "m\n---\nstack:\n  main.main\n    main.go:x", want: `line 5: invalid line number in "main.go:x"`},
+		{name: "missing causes", text: "m\n---\ncauses: (1 error)", want: "line 4: want causes"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := unmarshaler.DecodeText(tt.text)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("want error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
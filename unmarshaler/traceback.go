@@ -0,0 +1,156 @@
+package unmarshaler
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/resolver"
+)
+
+var tracebackGoroutinePattern = regexp.MustCompile(`^goroutine \d+(?: [^\[]*)? \[[^\]]*\]:$`)
+
+// NewTraceback creates a new Unmarshaler that unmarshals Go panic tracebacks,
+// decoded with DecodeTraceback, into errors of def.
+//
+// The goroutines of the causes also have the kind of def, so that their
+// stacks are kept.
+func NewTraceback(def errdef.Definition, opts ...Option) *Unmarshaler[string] {
+	return New(resolver.New(def), func(text string) (*DecodedData, error) {
+		decoded, err := DecodeTraceback(text)
+		if err != nil {
+			return nil, err
+		}
+		decoded.Kind = def.Kind()
+		for _, c := range decoded.Causes {
+			c.Kind = def.Kind()
+		}
+		return decoded, nil
+	}, opts...)
+}
+
+// DecodeTraceback decodes the traceback printed by the Go runtime when a
+// program crashes (e.g., collected from stderr) into DecodedData.
+//
+// The panic value (or the message of a fatal error) becomes the message, which
+// is the value of the last panic if panics were raised while panicking, and
+// the frames of the first goroutine, which is the one that panicked, become
+// the stack. The other goroutines, printed with GOTRACEBACK=all, become the
+// causes, with their headers (e.g., "goroutine 7 [chan receive]") as the
+// messages. The frame of the go statement that created a goroutine is
+// included at the end of its stack.
+//
+// Any output before the line starting with "panic: " or "fatal error: " and
+// after the goroutines is ignored.
+func DecodeTraceback(text string) (*DecodedData, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	start := -1
+	var msg string
+	for i, line := range lines {
+		var ok bool
+		if msg, ok = strings.CutPrefix(line, "panic: "); ok {
+			start = i
+			break
+		}
+		if msg, ok = strings.CutPrefix(line, "fatal error: "); ok {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("no panic or fatal error found")
+	}
+
+	// The runtime indents the subsequent lines of panic values, and the
+	// panics raised while panicking, with a tab.
+	messageLines := []string{msg}
+	pos := start + 1
+	for ; pos < len(lines) && strings.HasPrefix(lines[pos], "\t"); pos++ {
+		line := lines[pos][1:]
+		if msg, ok := strings.CutPrefix(line, "panic: "); ok {
+			messageLines = []string{msg}
+			continue
+		}
+		messageLines = append(messageLines, line)
+	}
+	msg = strings.Join(messageLines, "\n")
+	msg = strings.TrimSuffix(msg, " [recovered]")
+	msg = strings.TrimSuffix(msg, " [recovered, repanicked]")
+	decoded := &DecodedData{Message: msg}
+
+	goroutines := 0
+	for pos < len(lines) {
+		header := strings.TrimRight(lines[pos], " ")
+		pos++
+		if !tracebackGoroutinePattern.MatchString(header) {
+			continue
+		}
+
+		var frames []errdef.Frame
+		var err error
+		frames, pos, err = parseTracebackFrames(lines, pos)
+		if err != nil {
+			return nil, err
+		}
+
+		if goroutines == 0 {
+			decoded.Stack = frames
+		} else {
+			decoded.Causes = append(decoded.Causes, &DecodedData{
+				Message: strings.TrimSuffix(header, ":"),
+				Stack:   frames,
+			})
+		}
+		goroutines++
+	}
+	if goroutines == 0 {
+		return nil, fmt.Errorf("no goroutine found")
+	}
+	return decoded, nil
+}
+
+// parseTracebackFrames parses the frames of a goroutine starting at pos,
+// which are pairs of a function call and a tab-indented location, and
+// returns the position after them.
+func parseTracebackFrames(lines []string, pos int) ([]errdef.Frame, int, error) {
+	var frames []errdef.Frame
+	for pos < len(lines) {
+		line := strings.TrimRight(lines[pos], " \r")
+		if line == "" || strings.HasPrefix(line, "\t") {
+			break
+		}
+		pos++
+		if strings.HasPrefix(line, "...") { // e.g., "...additional frames elided..."
+			continue
+		}
+
+		fn := strings.TrimPrefix(line, "created by ")
+		if i := strings.Index(fn, " in goroutine "); i >= 0 {
+			fn = fn[:i]
+		}
+		if strings.HasSuffix(fn, ")") {
+			if i := strings.LastIndexByte(fn, '('); i > 0 {
+				fn = fn[:i]
+			}
+		}
+
+		if pos == len(lines) || !strings.HasPrefix(lines[pos], "\t") {
+			return frames, pos - 1, nil // not a frame, such as "exit status 2"
+		}
+		location, _, _ := strings.Cut(strings.TrimSpace(lines[pos]), " +0x")
+		i := strings.LastIndexByte(location, ':')
+		if i < 0 {
+			return nil, pos, fmt.Errorf("line %d: want file and line of %s", pos+1, fn)
+		}
+		lineNum, err := strconv.Atoi(location[i+1:])
+		if err != nil {
+			return nil, pos, fmt.Errorf("line %d: invalid line number in %q", pos+1, location)
+		}
+		frames = append(frames, errdef.Frame{Func: fn, File: location[:i], Line: lineNum})
+		pos++
+	}
+	return frames, pos, nil
+}
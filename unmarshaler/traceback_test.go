@@ -0,0 +1,131 @@
+package unmarshaler_test
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shiwano/errdef"
+	"github.com/shiwano/errdef/unmarshaler"
+)
+
+func TestNewTraceback(t *testing.T) {
+	if os.Getenv("ERRDEF_TRACEBACK_HELPER") == "1" {
+		var m map[string]int
+		m["crash"] = 1 // panics with a nil map assignment
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestNewTraceback$")
+	cmd.Env = append(os.Environ(), "ERRDEF_TRACEBACK_HELPER=1", "GOTRACEBACK=all")
+	out, _ := cmd.CombinedOutput()
+
+	errCrash := errdef.Define("crash")
+	u := unmarshaler.NewTraceback(errCrash)
+	got, err := u.Unmarshal(string(out))
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v\n%s", err, out)
+	}
+
+	if got.Error() != "assignment to entry in nil map" {
+		t.Errorf("want panic message, got %q", got.Error())
+	}
+	if got.Kind() != "crash" {
+		t.Errorf("want kind %q, got %q", "crash", got.Kind())
+	}
+
+	frames := got.Stack().Frames()
+	found := false
+	for _, f := range frames {
+		if f.Func == "github.com/shiwano/errdef/unmarshaler_test.TestNewTraceback" {
+			found = strings.HasSuffix(f.File, "traceback_test.go") && f.Line > 0
+		}
+	}
+	if !found {
+		t.Errorf("want frame of TestNewTraceback, got %v", frames)
+	}
+}
+
+func TestDecodeTraceback(t *testing.T) {
+	t.Run("all goroutines", func(t *testing.T) {
+		text := `starting server
+panic: first line
+	second line [recovered]
+	panic: again
+	and again
+[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x48f5a4]
+
+goroutine 1 gp=0xc000002380 m=0 mp=0x5c4e20 [running]:
+panic({0x4a3e60?, 0xc000014070?})
+	/usr/local/go/src/runtime/panic.go:785 +0x132
+main.(*Server).handle(0xc000010000, {0x4b2d3e, 0x5})
+	/app/server.go:42 +0x25
+main.main()
+	/app/main.go:10 +0x1d
+
+goroutine 7 [chan receive, 2 minutes]:
+main.worker(...)
+	/app/worker.go:5
+...additional frames elided...
+created by main.main in goroutine 1
+	/app/main.go:8 +0x3e
+exit status 2
+`
+		decoded, err := unmarshaler.DecodeTraceback(text)
+		if err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+
+		want := &unmarshaler.DecodedData{
+			Message: "again\nand again",
+			Stack: []errdef.Frame{
+				{Func: "panic", File: "/usr/local/go/src/runtime/panic.go", Line: 785},
+				{Func: "main.(*Server).handle", File: "/app/server.go", Line: 42},
+				{Func: "main.main", File: "/app/main.go", Line: 10},
+			},
+			Causes: []*unmarshaler.DecodedData{
+				{
+					Message: "goroutine 7 [chan receive, 2 minutes]",
+					Stack: []errdef.Frame{
+						{Func: "main.worker", File: "/app/worker.go", Line: 5},
+						{Func: "main.main", File: "/app/main.go", Line: 8},
+					},
+				},
+			},
+		}
+		if !reflect.DeepEqual(decoded, want) {
+			t.Errorf("want %+v, got %+v", want, decoded)
+		}
+	})
+
+	t.Run("fatal error", func(t *testing.T) {
+		text := "fatal error: all goroutines are asleep - deadlock!\n\ngoroutine 1 [chan receive]:\nmain.main()\n\t/app/main.go:4 +0x18\n"
+		decoded, err := unmarshaler.DecodeTraceback(text)
+		if err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		if decoded.Message != "all goroutines are asleep - deadlock!" || len(decoded.Stack) != 1 {
+			t.Errorf("want fatal error with one frame, got %+v", decoded)
+		}
+	})
+
+	errorTests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "no panic", text: "hello\n", want: "no panic or fatal error found"},
+		{name: "no goroutine", text: "panic: boom\n", want: "no goroutine found"},
+		{name: "invalid line number", text: "panic: boom\n\ngoroutine 1 [running]:\nmain.main()\n\tmain.go:x\n", want: `line 5: invalid line number in "main.go:x"`},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := unmarshaler.DecodeTraceback(tt.text)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("want error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/shiwano/errdef"
 	"github.com/shiwano/errdef/resolver"
@@ -32,6 +35,9 @@ type (
 		sentinelErrors  map[sentinelKey]error
 		customFieldKeys []errdef.FieldKey
 		strictMode      bool
+		streamPath      []string
+		maxRecordSize   int
+		parseStrings    bool
 	}
 
 	sentinelKey struct {
@@ -45,6 +51,8 @@ const errdefDefinitionTypeName = "*errdef.definition"
 var (
 	redactedStr   = errdef.Redact[any](nil).String()
 	redactedBytes = []byte("\"" + redactedStr + "\"")
+	maskedPrefix  = errdef.Mask[any](nil, 0).String()
+	hashedPattern = regexp.MustCompile(`^\[HASHED:[0-9a-f]{16}\]$`)
 )
 
 // New creates a new Unmarshaler with the given resolver, decoder, and options.
@@ -56,7 +64,7 @@ var (
 // For JSON deserialization, consider using NewJSON instead of New directly.
 func New[T any](resolver resolver.Resolver, decoder Decoder[T], opts ...Option) *Unmarshaler[T] {
 	u := &Unmarshaler[T]{
-		unmarshaler: &unmarshaler{},
+		unmarshaler: &unmarshaler{maxRecordSize: defaultMaxRecordSize},
 		resolver:    resolver,
 		decoder:     decoder,
 	}
@@ -97,12 +105,13 @@ func (d *Unmarshaler[T]) unmarshal(decoded *DecodedData) (UnmarshaledError, erro
 		keys := def.Fields().FindKeys(fieldName)
 		matched := false
 
-		// Redacted fields are stored in unknownFields to preserve their type information loss.
+		// Redacted and hashed fields are stored in unknownFields
+		// to preserve their type information loss.
 		// They can be accessed via FindKeys() followed by Get() with the returned unmarshaledFieldKey.
 		switch v := fieldValue.(type) {
 		case string:
-			if v == redactedStr {
-				unknownFields[fieldName] = redactedStr
+			if isRedactedString(v) {
+				unknownFields[fieldName] = v
 				continue
 			}
 		case []byte:
@@ -110,10 +119,14 @@ func (d *Unmarshaler[T]) unmarshal(decoded *DecodedData) (UnmarshaledError, erro
 				unknownFields[fieldName] = redactedStr
 				continue
 			}
+			if str, err := strconv.Unquote(string(v)); err == nil && isRedactedString(str) {
+				unknownFields[fieldName] = str
+				continue
+			}
 		}
 
 		for _, key := range keys {
-			if v, ok, err := tryConvertFieldValue(key, fieldValue); err != nil {
+			if v, ok, err := tryConvertFieldValue(key, fieldValue, d.parseStrings); err != nil {
 				return nil, err
 			} else if ok {
 				fields[key] = v
@@ -125,7 +138,7 @@ func (d *Unmarshaler[T]) unmarshal(decoded *DecodedData) (UnmarshaledError, erro
 		if !matched {
 			for _, customKey := range d.customFieldKeys {
 				if customKey.String() == fieldName {
-					if v, ok, err := tryConvertFieldValue(customKey, fieldValue); err != nil {
+					if v, ok, err := tryConvertFieldValue(customKey, fieldValue, d.parseStrings); err != nil {
 						return nil, err
 					} else if ok {
 						fields[customKey] = v
@@ -138,6 +151,13 @@ func (d *Unmarshaler[T]) unmarshal(decoded *DecodedData) (UnmarshaledError, erro
 				continue
 			}
 
+			// Masked fields cannot be told apart from real values starting with "****",
+			// so they are stored in unknownFields only if no field can hold them.
+			if str, ok := maskedString(fieldValue); ok {
+				unknownFields[fieldName] = str
+				continue
+			}
+
 			if d.strictMode {
 				return nil, ErrUnknownField.WithOptions(
 					fieldNameField(fieldName),
@@ -165,6 +185,7 @@ func (d *Unmarshaler[T]) unmarshal(decoded *DecodedData) (UnmarshaledError, erro
 		unknownFields: unknownFields,
 		stack:         decoded.Stack,
 		causes:        causes,
+		parseStrings:  d.parseStrings,
 	}, nil
 }
 
@@ -238,3 +259,23 @@ func (d *Unmarshaler[T]) resolveKind(kind errdef.Kind) (errdef.Definition, error
 	}
 	return def, nil
 }
+
+// isRedactedString reports whether s is the serialized form of
+// errdef.Redacted or errdef.Hashed.
+func isRedactedString(s string) bool {
+	return s == redactedStr || hashedPattern.MatchString(s)
+}
+
+// maskedString returns the string of v if it may be the serialized form of
+// errdef.Masked.
+func maskedString(v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, strings.HasPrefix(v, maskedPrefix)
+	case []byte:
+		if str, err := strconv.Unquote(string(v)); err == nil {
+			return str, strings.HasPrefix(str, maskedPrefix)
+		}
+	}
+	return "", false
+}
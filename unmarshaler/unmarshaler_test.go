@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"maps"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/shiwano/errdef"
@@ -417,6 +419,74 @@ func TestUnmarshaler_Fields_Redacted(t *testing.T) {
 	})
 }
 
+func TestUnmarshaler_Fields_MaskedAndHashed(t *testing.T) {
+	cardNumber, cardNumberFrom := errdef.DefineField[errdef.Masked[string]]("card_number")
+	userID, _ := errdef.DefineField[string]("user_id")
+	pin, pinFrom := errdef.DefineField[int]("pin")
+	note, noteFrom := errdef.DefineField[string]("note")
+
+	def := errdef.Define("payment_error",
+		cardNumber(errdef.Mask("unused", 0)), userID("unused"), pin(0), note("unused"))
+	r := resolver.New(def)
+	u := unmarshaler.NewJSON(r)
+
+	masked := errdef.Mask("4111111111111111", 4).String()
+	hashed := errdef.Hash("u123").String()
+	data := []byte(`{
+		"message": "payment failed",
+		"kind": "payment_error",
+		"fields": {
+			"card_number": "` + masked + `",
+			"user_id": "` + hashed + `",
+			"pin": "****",
+			"note": "****important****"
+		}
+	}`)
+
+	unmarshaled, err := u.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	t.Run("masked field is preserved", func(t *testing.T) {
+		keys := unmarshaled.Fields().FindKeys("card_number")
+		if len(keys) == 0 {
+			t.Fatal("want card_number field to be found")
+		}
+		if value, ok := unmarshaled.Fields().Get(keys[len(keys)-1]); !ok || value.Value() != masked {
+			t.Errorf("want card_number value %q, got %v", masked, value)
+		}
+		if _, ok := cardNumberFrom(unmarshaled); ok {
+			t.Error("want masked value not to be assigned to the typed field")
+		}
+	})
+
+	t.Run("masked value of non-string field is preserved", func(t *testing.T) {
+		if _, ok := pinFrom(unmarshaled); ok {
+			t.Error("want masked value not to be assigned to the typed field")
+		}
+		if v, ok := maps.Collect(unmarshaled.UnknownFields())["pin"]; !ok || v != "****" {
+			t.Errorf("want pin value %q, got %v", "****", v)
+		}
+	})
+
+	t.Run("real value starting with mask is kept", func(t *testing.T) {
+		if v, ok := noteFrom(unmarshaled); !ok || v != "****important****" {
+			t.Errorf("want note value %q, got %q (ok=%v)", "****important****", v, ok)
+		}
+	})
+
+	t.Run("hashed field is preserved", func(t *testing.T) {
+		fields := map[string]any{}
+		for k, v := range unmarshaled.UnknownFields() {
+			fields[k] = v
+		}
+		if fields["user_id"] != hashed {
+			t.Errorf("want user_id value %q, got %v", hashed, fields["user_id"])
+		}
+	})
+}
+
 func TestUnmarshaler_WithStandardSentinelErrors(t *testing.T) {
 	def := errdef.Define("test_error")
 	r := resolver.New(def)
@@ -1342,6 +1412,7 @@ func TestUnmarshaler_WithBuiltinFields(t *testing.T) {
 				"retryable": true,
 				"retry_after": 5000000000,
 				"unreportable": true,
+				"bug": true,
 				"exit_code": 1,
 				"help_url": "https://example.com/help",
 				"details": { "key": "value" }
@@ -1385,6 +1456,10 @@ func TestUnmarshaler_WithBuiltinFields(t *testing.T) {
 			t.Error("want unreportable to be true")
 		}
 
+		if got := errdef.IsBug(unmarshaled); !got {
+			t.Error("want bug to be true")
+		}
+
 		if got := errdef.ExitCodeFrom.OrZero(unmarshaled); got != 1 {
 			t.Errorf("want exit_code %d, got %d", 1, got)
 		}
@@ -1457,3 +1532,37 @@ func TestUnmarshaler_WithBuiltinFields(t *testing.T) {
 		}
 	})
 }
+
+func TestUnmarshaler_Catalog(t *testing.T) {
+	c, err := errdef.LoadCatalog(strings.NewReader(`{
+		"fields": [{"name": "plugin_id", "type": "string"}, {"name": "attempts", "type": "int"}],
+		"kinds": [{"kind": "plugin_failed", "http_status": 502, "retryable": true}]
+	}`))
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+	u := unmarshaler.NewJSON(resolver.FromCatalog(c), unmarshaler.WithStrictMode(), unmarshaler.WithCustomFields(c.FieldKeys()...))
+
+	jsonData := `{
+		"message": "plugin crashed",
+		"kind": "plugin_failed",
+		"fields": {"http_status": 502, "retryable": true, "plugin_id": "p1", "attempts": 3}
+	}`
+
+	unmarshaled, err := u.Unmarshal([]byte(jsonData))
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+
+	def, _ := c.Definition("plugin_failed")
+	if !errors.Is(unmarshaled, def) {
+		t.Error("want unmarshaled error to match the catalog definition")
+	}
+	if !errdef.IsRetryable(unmarshaled) {
+		t.Error("want retryable")
+	}
+	key, _ := c.FieldKey("attempts")
+	if v, ok := unmarshaled.Fields().Get(key); !ok || v.Value() != 3 {
+		t.Errorf("want attempts 3, got %v", v)
+	}
+}